@@ -1,130 +1,84 @@
 package discovery
 
 import (
-	"context"
 	"fmt"
+	"strings"
+
 	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/internal/discovery/sinks"
 	"github.com/goletan/services-library/internal/discovery/strategies"
+	"github.com/goletan/services-library/internal/metrics"
 	"github.com/goletan/services-library/shared/types"
-	"go.uber.org/zap"
-	"sync"
 )
 
-type CompositeDiscovery struct {
-	strategies []types.Strategy
-	logger     *logger.ZapLogger
-}
-
-func NewCompositeDiscovery(log *logger.ZapLogger, config *types.ServicesConfig) *CompositeDiscovery {
-	strats, err := initStrategies(log, config)
-	if err != nil {
-		log.Fatal("Failed to initialize discovery strategies", zap.Error(err))
-	}
-
-	return &CompositeDiscovery{
-		strategies: strats,
-		logger:     log,
-	}
-}
-
-// AddStrategy support for dynamic updates of strategies.
-func (cd *CompositeDiscovery) AddStrategy(strategy types.Strategy) {
-	cd.logger.Info("Adding discovery strategy", zap.String("strategy", strategy.Name()))
-	cd.strategies = append(cd.strategies, strategy)
-}
-
-func (cd *CompositeDiscovery) RemoveStrategy(name string) error {
-	for i, strategy := range cd.strategies {
-		if strategy.Name() == name {
-			cd.logger.Info("Removing discovery strategy", zap.String("strategy", name))
-			cd.strategies = append(cd.strategies[:i], cd.strategies[i+1:]...)
-			return nil
-		}
-	}
-	return fmt.Errorf("strategy not found: %s", name)
-}
-
-func (cd *CompositeDiscovery) Discover(ctx context.Context, filter *types.Filter) ([]types.ServiceEndpoint, error) {
-	var discovered []types.ServiceEndpoint
-
-	for _, strategy := range cd.strategies {
-		cd.logger.Info("Attempting service discovery using strategy", zap.String("strategy", strategy.Name()))
-		endpoints, err := strategy.Discover(ctx, filter)
-		if err != nil {
-			cd.logger.Warn("Discovery strategy failed", zap.String("strategy", strategy.Name()), zap.Error(err))
-		} else {
-			discovered = append(discovered, endpoints...)
-			cd.logger.Info("Discovered services", zap.Int("count", len(endpoints)))
+// newStrategy builds the Strategy implementation named by strategyConfig.Name.
+func newStrategy(log *logger.ZapLogger, met *metrics.ServicesMetrics, strategyConfig types.StrategyConfig) (types.Strategy, error) {
+	switch strategyConfig.Name {
+	case "kubernetes":
+		return strategies.NewKubernetesStrategy(log, strategyConfig.Namespace), nil
+	case "docker":
+		return strategies.NewDockerSwarmStrategy(log, strategyConfig.Network), nil
+	case "dns":
+		return strategies.NewDNSStrategy(log, strategyConfig.Domain).
+			WithServiceProto(strategyConfig.Service, strategyConfig.Proto).
+			WithRefreshInterval(strategyConfig.RefreshInterval), nil
+	case "consul":
+		return strategies.NewConsulStrategy(log, strategyConfig.Consul)
+	case "file":
+		return strategies.NewFileStrategy(log, met, strategyConfig.Files, strategyConfig.RefreshInterval), nil
+	case "composite":
+		subStrategies := make([]types.Strategy, 0, len(strategyConfig.Strategies))
+		for _, subConfig := range strategyConfig.Strategies {
+			sub, err := newStrategy(log, met, subConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize composite sub-strategy %s: %w", subConfig.Name, err)
+			}
+			subStrategies = append(subStrategies, sub)
 		}
+		return strategies.NewCompositeStrategy(log, subStrategies, strategies.MergePolicy(strategyConfig.MergePolicy)), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy: %s", strategyConfig.Name)
 	}
-
-	return discovered, nil
 }
 
-func (cd *CompositeDiscovery) Watch(ctx context.Context, filter *types.Filter) (<-chan types.ServiceEvent, error) {
-	// Single aggregated channel for service events-service
-	aggregatedEvents := make(chan types.ServiceEvent)
-
-	// WaitGroup to synchronize the goroutines
-	var wg sync.WaitGroup
-
-	// Context to handle cancellations for individual strategies
-	watchCtx, cancel := context.WithCancel(ctx)
-
-	// Start a goroutine to collect events-service from each strategy
-	for _, strategy := range cd.strategies {
-		wg.Add(1)
-		go func(strategy types.Strategy) {
-			defer wg.Done()
-			eventCh, err := strategy.Watch(watchCtx, filter)
-			if err != nil {
-				cd.logger.Warn("Failed to start watcher for strategy",
-					zap.String("strategy", fmt.Sprintf("%T", strategy)),
-					zap.Error(err))
-				return
-			}
-
-			// Forward events-service to the aggregated channel
-			for event := range eventCh {
-				select {
-				case aggregatedEvents <- event:
-				case <-watchCtx.Done():
-					return
-				}
-			}
-		}(strategy)
+// newSink builds the Sink implementation named by sinkConfig.Name.
+func newSink(log *logger.ZapLogger, sinkConfig types.SinkConfig) (sinks.Sink, error) {
+	switch sinkConfig.Name {
+	case "dns":
+		return sinks.NewDNSSink(log, sinkConfig.DNS)
+	default:
+		return nil, fmt.Errorf("unknown sink: %s", sinkConfig.Name)
 	}
-
-	// Start a goroutine to close the aggregated channel when all watchers are done
-	go func() {
-		wg.Wait()
-		cancel() // Ensure all resources are released
-		close(aggregatedEvents)
-	}()
-
-	return aggregatedEvents, nil
 }
 
-func initStrategies(logger *logger.ZapLogger, config *types.ServicesConfig) ([]types.Strategy, error) {
-	var strats []types.Strategy
-
-	for _, strategyConfig := range config.Discovery.Strategies {
-		logger.Info("Initializing discovery strategy config", zap.Any("strategyConfig", strategyConfig))
-		switch strategyConfig.Name {
-		case "kubernetes":
-			strats = append(strats, strategies.NewKubernetesStrategy(logger, strategyConfig.Namespace))
-			logger.Info("Using Kubernetes strategy", zap.String("namespace", strategyConfig.Namespace))
-		case "docker":
-			strats = append(strats, strategies.NewDockerSwarmStrategy(logger, strategyConfig.Network))
-			logger.Info("Using Docker Swarm strategy", zap.String("network", strategyConfig.Network))
-		case "dns":
-			strats = append(strats, strategies.NewDNSStrategy(logger, strategyConfig.Domain))
-			logger.Info("Using DNS strategy", zap.String("domain", strategyConfig.Domain))
-		default:
-			logger.Warn("Unknown strategy specified, defaulting to Kubernetes.", zap.String("strategy", strategyConfig.Name))
-			return nil, fmt.Errorf("unknown strategy: %s", strategyConfig.Name)
+// sourceKey derives a stable, human-readable source identifier for a strategy config, combining
+// the strategy name with whatever sub-source it targets (namespace, network, domain, ...) so that
+// two providers of the same type don't collide in the Manager's per-source snapshots.
+func sourceKey(strategyConfig types.StrategyConfig, index int) string {
+	var subSource string
+	switch strategyConfig.Name {
+	case "kubernetes":
+		subSource = strategyConfig.Namespace
+	case "docker":
+		subSource = strategyConfig.Network
+	case "dns":
+		subSource = strategyConfig.Domain
+	case "consul":
+		subSource = strategyConfig.Consul.Service
+	case "file":
+		if len(strategyConfig.Files) > 0 {
+			subSource = strategyConfig.Files[0]
+		}
+	case "composite":
+		names := make([]string, len(strategyConfig.Strategies))
+		for i, sub := range strategyConfig.Strategies {
+			names[i] = sub.Name
 		}
+		subSource = strings.Join(names, "+")
 	}
 
-	return strats, nil
+	if subSource == "" {
+		return fmt.Sprintf("%s/%d", strategyConfig.Name, index)
+	}
+	return fmt.Sprintf("%s/%s", strategyConfig.Name, subSource)
 }
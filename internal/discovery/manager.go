@@ -0,0 +1,355 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/internal/discovery/sinks"
+	"github.com/goletan/services-library/internal/metrics"
+	"github.com/goletan/services-library/internal/relabel"
+	"github.com/goletan/services-library/shared/types"
+	"go.uber.org/zap"
+)
+
+// reconciler is implemented by sinks that run their own periodic reconciliation loop against the
+// manager's live view of endpoints (e.g. DNSSink).
+type reconciler interface {
+	Run(ctx context.Context, live func() []types.ServiceEndpoint)
+}
+
+const defaultSyncPeriod = 30 * time.Second
+
+// provider is a running discovery strategy scoped to a stable source key.
+type provider struct {
+	source   string
+	strategy types.Strategy
+	cancel   context.CancelFunc
+}
+
+// Manager runs one goroutine per configured provider, keeps a per-source snapshot of each
+// provider's last known endpoints, and publishes ADDED/MODIFIED/DELETED events on a single
+// aggregated channel. A slow consumer never blocks the manager indefinitely: updates that can't
+// be delivered immediately are dropped and
+// recovered by the periodic sync_period resync, which re-emits the full consolidated view.
+type Manager struct {
+	logger  *logger.ZapLogger
+	metrics *metrics.ServicesMetrics
+
+	mu             sync.Mutex
+	providers      map[string]*provider
+	snapshots      map[string]map[string]types.ServiceEndpoint
+	filter         *types.Filter
+	relabelConfigs []types.RelabelConfig
+	sinks          []sinks.Sink
+
+	syncPeriod time.Duration
+	parentCtx  context.Context
+	started    bool
+	updates    chan types.ServiceEvent
+	providerWG sync.WaitGroup
+}
+
+// NewManager builds a Manager and its initial set of providers from cfg, recording discovery
+// health (attempts, endpoint counts, watch events, dropped updates) through met.
+func NewManager(log *logger.ZapLogger, cfg *types.ServicesConfig, met *metrics.ServicesMetrics) *Manager {
+	syncPeriod := cfg.Discovery.SyncPeriod
+	if syncPeriod <= 0 {
+		syncPeriod = defaultSyncPeriod
+	}
+
+	m := &Manager{
+		logger:     log,
+		metrics:    met,
+		providers:  make(map[string]*provider),
+		snapshots:  make(map[string]map[string]types.ServiceEndpoint),
+		syncPeriod: syncPeriod,
+		updates:    make(chan types.ServiceEvent),
+	}
+
+	if err := m.ApplyConfig(cfg); err != nil {
+		log.Fatal("Failed to initialize discovery providers", zap.Error(err))
+	}
+
+	for _, sinkConfig := range cfg.Discovery.Sinks {
+		sink, err := newSink(log, sinkConfig)
+		if err != nil {
+			log.Fatal("Failed to initialize discovery sink", zap.String("sink", sinkConfig.Name), zap.Error(err))
+		}
+		m.sinks = append(m.sinks, sink)
+		log.Info("Added discovery sink", zap.String("sink", sinkConfig.Name))
+	}
+
+	return m
+}
+
+// ApplyConfig reconciles the manager's providers with cfg: providers no longer present are
+// stopped, new ones are created (and started, if the manager is already running), and unchanged
+// providers are left alone. Safe to call while Watch is active.
+func (m *Manager) ApplyConfig(cfg *types.ServicesConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.relabelConfigs = cfg.Discovery.Relabel
+
+	wanted := make(map[string]types.StrategyConfig, len(cfg.Discovery.Strategies))
+	for i, strategyConfig := range cfg.Discovery.Strategies {
+		wanted[sourceKey(strategyConfig, i)] = strategyConfig
+	}
+
+	for source, p := range m.providers {
+		if _, ok := wanted[source]; !ok {
+			m.stopProviderLocked(source, p)
+		}
+	}
+
+	for source, strategyConfig := range wanted {
+		if _, exists := m.providers[source]; exists {
+			continue
+		}
+
+		strategy, err := newStrategy(m.logger, m.metrics, strategyConfig)
+		if err != nil {
+			return fmt.Errorf("failed to initialize provider %s: %w", source, err)
+		}
+
+		p := &provider{source: source, strategy: strategy}
+		m.providers[source] = p
+		m.logger.Info("Added discovery provider", zap.String("source", source))
+
+		if m.started {
+			m.startProviderLocked(p)
+		}
+	}
+
+	return nil
+}
+
+func (m *Manager) stopProviderLocked(source string, p *provider) {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	delete(m.providers, source)
+	delete(m.snapshots, source)
+	m.logger.Info("Removed discovery provider", zap.String("source", source))
+}
+
+func (m *Manager) startProviderLocked(p *provider) {
+	if m.parentCtx == nil {
+		return
+	}
+
+	providerCtx, cancel := context.WithCancel(m.parentCtx)
+	p.cancel = cancel
+
+	m.providerWG.Add(1)
+	go func() {
+		defer m.providerWG.Done()
+		m.runProvider(providerCtx, p)
+	}()
+}
+
+// Discover performs a one-shot discovery across all current providers and returns the
+// consolidated set of endpoints.
+func (m *Manager) Discover(ctx context.Context, filter *types.Filter) ([]types.ServiceEndpoint, error) {
+	m.mu.Lock()
+	providers := make([]*provider, 0, len(m.providers))
+	for _, p := range m.providers {
+		providers = append(providers, p)
+	}
+	m.mu.Unlock()
+
+	var discovered []types.ServiceEndpoint
+	for _, p := range providers {
+		m.logger.Info("Attempting service discovery using strategy", zap.String("source", p.source))
+		endpoints, err := p.strategy.Discover(ctx, filter)
+		if err != nil {
+			m.logger.Warn("Discovery provider failed", zap.String("source", p.source), zap.Error(err))
+			m.metrics.ObserveDiscoveryAttempt(p.strategy.Name(), "error")
+			continue
+		}
+
+		discovered = append(discovered, endpoints...)
+		m.metrics.ObserveDiscoveryAttempt(p.strategy.Name(), "success")
+		m.metrics.SetDiscoveryEndpoints(p.strategy.Name(), len(endpoints))
+		m.logger.Info("Discovered services", zap.String("source", p.source), zap.Int("count", len(endpoints)))
+	}
+
+	m.mu.Lock()
+	relabelConfigs := m.relabelConfigs
+	m.mu.Unlock()
+
+	relabeled, err := relabel.Process(discovered, relabelConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to relabel discovered endpoints: %w", err)
+	}
+
+	return relabeled, nil
+}
+
+// Watch starts the manager's background provider and sync-period goroutines on first call, and
+// returns the aggregated event channel. ctx governs the lifetime of every provider; it is
+// cancelled once and not replaced on subsequent calls.
+func (m *Manager) Watch(ctx context.Context, filter *types.Filter) (<-chan types.ServiceEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.started {
+		return m.updates, nil
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	m.parentCtx = runCtx
+	m.filter = filter
+	m.started = true
+
+	for _, p := range m.providers {
+		m.startProviderLocked(p)
+	}
+
+	for _, sink := range m.sinks {
+		if r, ok := sink.(reconciler); ok {
+			go r.Run(runCtx, m.allEndpoints)
+		}
+	}
+
+	go func() {
+		<-runCtx.Done()
+		m.logger.Info("Stopping discovery manager...")
+		m.providerWG.Wait()
+		close(m.updates)
+	}()
+
+	m.providerWG.Add(1)
+	go func() {
+		defer m.providerWG.Done()
+		m.runSyncLoop(runCtx, cancel)
+	}()
+
+	return m.updates, nil
+}
+
+func (m *Manager) runProvider(ctx context.Context, p *provider) {
+	eventCh, err := p.strategy.Watch(ctx, m.filter)
+	if err != nil {
+		m.logger.Warn("Failed to start watcher for provider", zap.String("source", p.source), zap.Error(err))
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			m.metrics.ObserveWatchEvent(p.strategy.Name(), event.Type)
+			m.applyEvent(p.source, event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *Manager) applyEvent(source string, event types.ServiceEvent) {
+	m.mu.Lock()
+	relabelConfigs := m.relabelConfigs
+	m.mu.Unlock()
+
+	relabeled, err := relabel.Process([]types.ServiceEndpoint{event.Service}, relabelConfigs)
+	if err != nil {
+		m.logger.Warn("Failed to relabel discovery event, dropping it", zap.String("source", source), zap.Error(err))
+		return
+	}
+	if len(relabeled) == 0 {
+		// Filtered out by a keep/drop rule; nothing to apply.
+		return
+	}
+	event.Service = relabeled[0]
+
+	m.mu.Lock()
+	snapshot, ok := m.snapshots[source]
+	if !ok {
+		snapshot = make(map[string]types.ServiceEndpoint)
+		m.snapshots[source] = snapshot
+	}
+
+	key := endpointKey(event.Service)
+	if event.Type == "DELETED" {
+		delete(snapshot, key)
+	} else {
+		snapshot[key] = event.Service
+	}
+	m.mu.Unlock()
+
+	m.fanOutEvent(event)
+	m.send(event)
+}
+
+// fanOutEvent delivers event to every registered sink, in addition to the Registry via the
+// aggregated updates channel, logging (rather than failing the provider) on a sink error.
+func (m *Manager) fanOutEvent(event types.ServiceEvent) {
+	for _, sink := range m.sinks {
+		if err := sink.OnEvent(event); err != nil {
+			m.logger.Warn("Discovery sink failed to handle event",
+				zap.String("sink", sink.Name()), zap.String("type", event.Type), zap.Error(err))
+		}
+	}
+}
+
+// runSyncLoop periodically re-emits the full consolidated view of every provider's snapshot, so
+// consumers can self-heal after a dropped update. updates is closed elsewhere, once every provider
+// goroutine has returned, so sends from runProvider/applyEvent can never race a closed channel.
+func (m *Manager) runSyncLoop(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+
+	ticker := time.NewTicker(m.syncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.resync()
+		}
+	}
+}
+
+func (m *Manager) resync() {
+	for _, endpoint := range m.allEndpoints() {
+		m.send(types.ServiceEvent{Type: "MODIFIED", Service: endpoint})
+	}
+}
+
+// allEndpoints returns the consolidated set of every provider's last known endpoints. It is used
+// by resync and passed to sinks' own reconciliation loops as their "list all live endpoints" view.
+func (m *Manager) allEndpoints() []types.ServiceEndpoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var endpoints []types.ServiceEndpoint
+	for _, snapshot := range m.snapshots {
+		for _, endpoint := range snapshot {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// send delivers event without blocking: if the consumer isn't ready, the update is dropped and
+// counted, to be recovered by the next resync.
+func (m *Manager) send(event types.ServiceEvent) {
+	select {
+	case m.updates <- event:
+	default:
+		m.metrics.SDUpdatesDroppedTotal.Inc()
+		m.logger.Warn("Dropping discovery update, consumer is not keeping up",
+			zap.String("type", event.Type), zap.String("service", event.Service.Name))
+	}
+}
+
+func endpointKey(endpoint types.ServiceEndpoint) string {
+	return endpoint.Name + "/" + endpoint.Address
+}
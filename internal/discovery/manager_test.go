@@ -0,0 +1,130 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/goletan/services-library/shared/types"
+)
+
+// newTestManager builds a Manager with a buffered updates channel so send() always succeeds
+// without a concurrent reader, bypassing NewManager (which would dial out to build real
+// providers/sinks from config).
+func newTestManager() *Manager {
+	return &Manager{
+		providers: make(map[string]*provider),
+		snapshots: make(map[string]map[string]types.ServiceEndpoint),
+		updates:   make(chan types.ServiceEvent, 16),
+	}
+}
+
+func TestManager_ApplyEvent_UpdatesSnapshot(t *testing.T) {
+	m := newTestManager()
+
+	added := types.ServiceEndpoint{Name: "svc", Address: "10.0.0.1"}
+	m.applyEvent("source-a", types.ServiceEvent{Type: "ADDED", Service: added})
+
+	snapshot := m.snapshots["source-a"]
+	if len(snapshot) != 1 {
+		t.Fatalf("expected 1 endpoint in snapshot, got %d", len(snapshot))
+	}
+	if _, ok := snapshot[endpointKey(added)]; !ok {
+		t.Errorf("expected snapshot to contain %+v", added)
+	}
+
+	select {
+	case event := <-m.updates:
+		if event.Type != "ADDED" || event.Service.Name != "svc" {
+			t.Errorf("expected ADDED event for svc, got %+v", event)
+		}
+	default:
+		t.Fatal("expected applyEvent to forward the event onto updates")
+	}
+}
+
+func TestManager_ApplyEvent_DeletedRemovesFromSnapshot(t *testing.T) {
+	m := newTestManager()
+
+	endpoint := types.ServiceEndpoint{Name: "svc", Address: "10.0.0.1"}
+	m.snapshots["source-a"] = map[string]types.ServiceEndpoint{endpointKey(endpoint): endpoint}
+
+	m.applyEvent("source-a", types.ServiceEvent{Type: "DELETED", Service: endpoint})
+
+	if len(m.snapshots["source-a"]) != 0 {
+		t.Errorf("expected snapshot to be empty after DELETED, got %+v", m.snapshots["source-a"])
+	}
+}
+
+func TestManager_ApplyEvent_RelabelDrop(t *testing.T) {
+	m := newTestManager()
+	m.relabelConfigs = []types.RelabelConfig{
+		{SourceLabels: []string{"__name__"}, Action: types.RelabelActionDrop, Regex: "svc"},
+	}
+
+	m.applyEvent("source-a", types.ServiceEvent{Type: "ADDED", Service: types.ServiceEndpoint{Name: "svc"}})
+
+	if len(m.snapshots["source-a"]) != 0 {
+		t.Errorf("expected dropped endpoint to never reach the snapshot, got %+v", m.snapshots["source-a"])
+	}
+	select {
+	case event := <-m.updates:
+		t.Errorf("expected no event for a relabel-dropped endpoint, got %+v", event)
+	default:
+	}
+}
+
+func TestManager_Resync_ReemitsAllSnapshots(t *testing.T) {
+	m := newTestManager()
+	m.snapshots["source-a"] = map[string]types.ServiceEndpoint{
+		"svc-a/10.0.0.1": {Name: "svc-a", Address: "10.0.0.1"},
+	}
+	m.snapshots["source-b"] = map[string]types.ServiceEndpoint{
+		"svc-b/10.0.0.2": {Name: "svc-b", Address: "10.0.0.2"},
+	}
+
+	m.resync()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-m.updates:
+			if event.Type != "MODIFIED" {
+				t.Errorf("expected resync to emit MODIFIED events, got %q", event.Type)
+			}
+			seen[event.Service.Name] = true
+		default:
+			t.Fatalf("expected resync to emit 2 events, only got %d", i)
+		}
+	}
+	if !seen["svc-a"] || !seen["svc-b"] {
+		t.Errorf("expected resync to re-emit every source's endpoints, got %+v", seen)
+	}
+}
+
+func TestSourceKey_UniquePerSubSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		config types.StrategyConfig
+		index  int
+	}{
+		{name: "kubernetes namespace", config: types.StrategyConfig{Name: "kubernetes", Namespace: "payments"}},
+		{name: "docker network", config: types.StrategyConfig{Name: "docker", Network: "ingress"}},
+		{name: "dns domain", config: types.StrategyConfig{Name: "dns", Domain: "example.com"}},
+		{name: "file falls back to index when no files configured", config: types.StrategyConfig{Name: "file"}, index: 2},
+	}
+
+	seen := make(map[string]bool)
+	for _, tt := range tests {
+		key := sourceKey(tt.config, tt.index)
+		if seen[key] {
+			t.Errorf("%s: expected a unique source key, got collision on %q", tt.name, key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestSourceKey_SameConfigIsStable(t *testing.T) {
+	cfg := types.StrategyConfig{Name: "kubernetes", Namespace: "payments"}
+	if sourceKey(cfg, 0) != sourceKey(cfg, 1) {
+		t.Errorf("expected sourceKey to be stable across calls for the same config regardless of index")
+	}
+}
@@ -0,0 +1,229 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/shared/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDNSReconcileTick = 1 * time.Minute
+	defaultDNSTTL           = 30 * time.Second
+	defaultDNSPriority      = 10
+	defaultDNSWeight        = 10
+)
+
+// dnsRecord is the SkyDNS-style JSON value stored at each etcd key.
+type dnsRecord struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port,omitempty"`
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+	TTL      int    `json:"ttl,omitempty"`
+}
+
+// DNSSink publishes discovered endpoints as SRV/A-style records into an etcd v3 key space under
+// "/<domain>/<namespace>/<service>/<port>", the layout kube2sky/SkyDNS used to serve cluster DNS
+// out of etcd. Each of a ServiceEndpoint's Ports gets its own key, so a DNS server walking the
+// tree can resolve per-port SRV entries independently; an endpoint with no ports falls back to a
+// single bare A-style record.
+type DNSSink struct {
+	logger *logger.ZapLogger
+	config types.DNSSinkConfig
+	client *clientv3.Client
+
+	mu   sync.Mutex
+	keys map[string]map[string]bool // endpoint key -> set of etcd keys currently published for it
+}
+
+// NewDNSSink creates a DNSSink connected to the configured etcd endpoints.
+func NewDNSSink(log *logger.ZapLogger, cfg types.DNSSinkConfig) (*DNSSink, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create etcd client for DNS sink: %w", err)
+	}
+
+	return &DNSSink{
+		logger: log,
+		config: cfg,
+		client: client,
+		keys:   make(map[string]map[string]bool),
+	}, nil
+}
+
+// Name returns the name of the sink.
+func (s *DNSSink) Name() string {
+	return "dns"
+}
+
+// OnEvent upserts or deletes the etcd keys for a single ADDED/MODIFIED/DELETED service event.
+func (s *DNSSink) OnEvent(event types.ServiceEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := endpointKey(event.Service)
+	if event.Type == "DELETED" {
+		return s.deleteEndpoint(ctx, key)
+	}
+	return s.upsertEndpoint(ctx, key, event.Service)
+}
+
+// Reconcile upserts every endpoint and deletes any key this sink previously published that isn't
+// among them, recovering from DELETED events OnEvent missed.
+func (s *DNSSink) Reconcile(endpoints []types.ServiceEndpoint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	live := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		key := endpointKey(endpoint)
+		live[key] = true
+		if err := s.upsertEndpoint(ctx, key, endpoint); err != nil {
+			s.logger.Warn("Failed to reconcile DNS sink endpoint", zap.String("service", endpoint.Name), zap.Error(err))
+		}
+	}
+
+	s.mu.Lock()
+	var stale []string
+	for key := range s.keys {
+		if !live[key] {
+			stale = append(stale, key)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, key := range stale {
+		if err := s.deleteEndpoint(ctx, key); err != nil {
+			s.logger.Warn("Failed to prune stale DNS sink record", zap.String("endpoint", key), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// Run periodically reconciles against live(), the kube2sky-style watch-loop safety net that
+// recovers the sink's view even if individual OnEvent calls were missed.
+func (s *DNSSink) Run(ctx context.Context, live func() []types.ServiceEndpoint) {
+	tick := s.config.ReconcileTick
+	if tick <= 0 {
+		tick = defaultDNSReconcileTick
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Reconcile(live()); err != nil {
+				s.logger.Warn("DNS sink reconciliation failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// upsertEndpoint writes one record per port of endpoint (or a single bare record if it has none),
+// then deletes any previously published key for this endpoint that's no longer current.
+func (s *DNSSink) upsertEndpoint(ctx context.Context, key string, endpoint types.ServiceEndpoint) error {
+	ttl := int(s.config.TTL / time.Second)
+	if ttl <= 0 {
+		ttl = int(defaultDNSTTL / time.Second)
+	}
+
+	published := make(map[string]bool)
+	ports := endpoint.Ports
+	if len(ports) == 0 {
+		ports = []types.ServicePort{{}}
+	}
+
+	for _, port := range ports {
+		suffix := port.Name
+		if suffix == "" && port.Port != 0 {
+			suffix = strconv.Itoa(port.Port)
+		}
+
+		etcdKey := s.recordKey(endpoint, suffix)
+		record := dnsRecord{Host: endpoint.Address, Port: port.Port, Priority: defaultDNSPriority, Weight: defaultDNSWeight, TTL: ttl}
+		if err := s.putRecord(ctx, etcdKey, record); err != nil {
+			return err
+		}
+		published[etcdKey] = true
+	}
+
+	s.mu.Lock()
+	previous := s.keys[key]
+	s.keys[key] = published
+	s.mu.Unlock()
+
+	for oldKey := range previous {
+		if !published[oldKey] {
+			if _, err := s.client.Delete(ctx, oldKey); err != nil {
+				s.logger.Warn("Failed to delete superseded DNS record", zap.String("key", oldKey), zap.Error(err))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *DNSSink) deleteEndpoint(ctx context.Context, key string) error {
+	s.mu.Lock()
+	published, ok := s.keys[key]
+	delete(s.keys, key)
+	s.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	for etcdKey := range published {
+		if _, err := s.client.Delete(ctx, etcdKey); err != nil {
+			return fmt.Errorf("failed to delete DNS record %s: %w", etcdKey, err)
+		}
+	}
+	return nil
+}
+
+func (s *DNSSink) putRecord(ctx context.Context, key string, record dnsRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal DNS record for %s: %w", key, err)
+	}
+
+	if _, err := s.client.Put(ctx, key, string(data)); err != nil {
+		return fmt.Errorf("failed to write DNS record %s: %w", key, err)
+	}
+	return nil
+}
+
+// recordKey builds the "/<domain>/<namespace>/<service>[/<port>]" etcd key for endpoint.
+func (s *DNSSink) recordKey(endpoint types.ServiceEndpoint, portSuffix string) string {
+	segments := []string{s.config.Domain, s.config.Namespace, endpoint.Name, portSuffix}
+
+	var nonEmpty []string
+	for _, seg := range segments {
+		if seg != "" {
+			nonEmpty = append(nonEmpty, seg)
+		}
+	}
+
+	return "/" + strings.Join(nonEmpty, "/")
+}
+
+func endpointKey(endpoint types.ServiceEndpoint) string {
+	return endpoint.Name + "/" + endpoint.Address
+}
@@ -0,0 +1,15 @@
+package sinks
+
+import "github.com/goletan/services-library/shared/types"
+
+// Sink receives discovered service events in addition to the Registry, for fanning updates out
+// to external systems (DNS, a service mesh control plane, ...).
+type Sink interface {
+	// Name identifies the sink, for logging.
+	Name() string
+	// OnEvent applies a single ADDED/MODIFIED/DELETED event.
+	OnEvent(event types.ServiceEvent) error
+	// Reconcile replaces a sink's view of live endpoints wholesale, pruning anything it published
+	// that isn't present in endpoints, to recover from events OnEvent missed.
+	Reconcile(endpoints []types.ServiceEndpoint) error
+}
@@ -0,0 +1,219 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/shared/types"
+	"go.uber.org/zap"
+)
+
+// MergePolicy resolves conflicting endpoints that two or more sub-strategies of a
+// CompositeStrategy report for the same dedupe key.
+type MergePolicy string
+
+const (
+	// PreferFirst keeps whichever sub-strategy (in configuration order) reported the endpoint
+	// first. This is the default.
+	PreferFirst MergePolicy = "prefer_first"
+	// PreferNewest keeps the last endpoint observed for a key, letting a later sub-strategy's
+	// view override an earlier one.
+	PreferNewest MergePolicy = "prefer_newest"
+	// Union merges Tags, MetaLabels, and Ports from every sub-strategy that reported the key,
+	// preferring the first strategy's value on a tag/label collision.
+	Union MergePolicy = "union"
+)
+
+// CompositeStrategy fans Discover/Watch out to multiple underlying strategies concurrently and
+// unifies their results, so e.g. Kubernetes and Docker Swarm can be composed in a hybrid
+// environment instead of each being used independently.
+type CompositeStrategy struct {
+	logger     *logger.ZapLogger
+	strategies []types.Strategy
+	policy     MergePolicy
+}
+
+// NewCompositeStrategy creates a CompositeStrategy over subStrategies. An empty policy defaults
+// to PreferFirst.
+func NewCompositeStrategy(log *logger.ZapLogger, subStrategies []types.Strategy, policy MergePolicy) *CompositeStrategy {
+	if policy == "" {
+		policy = PreferFirst
+	}
+
+	return &CompositeStrategy{
+		logger:     log,
+		strategies: subStrategies,
+		policy:     policy,
+	}
+}
+
+func (c *CompositeStrategy) Name() string {
+	return "composite"
+}
+
+// Discover runs every sub-strategy's Discover concurrently and merges their endpoints, resolving
+// overlapping endpoints (same dedupe key found by more than one sub-strategy) per c.policy.
+func (c *CompositeStrategy) Discover(ctx context.Context, filter *types.Filter) ([]types.ServiceEndpoint, error) {
+	type sourceResult struct {
+		source    string
+		endpoints []types.ServiceEndpoint
+		err       error
+	}
+
+	results := make([]sourceResult, len(c.strategies))
+	var wg sync.WaitGroup
+	for i, strategy := range c.strategies {
+		i, strategy := i, strategy
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			endpoints, err := strategy.Discover(ctx, filter)
+			results[i] = sourceResult{source: strategy.Name(), endpoints: endpoints, err: err}
+		}()
+	}
+	wg.Wait()
+
+	merged := make(map[string]types.ServiceEndpoint)
+	var order []string
+	for _, result := range results {
+		if result.err != nil {
+			c.logger.Warn("Composite sub-strategy discover failed", zap.String("source", result.source), zap.Error(result.err))
+			continue
+		}
+
+		for _, endpoint := range result.endpoints {
+			key := dedupeKey(endpoint)
+			existing, seen := merged[key]
+			if !seen {
+				merged[key] = endpoint
+				order = append(order, key)
+				continue
+			}
+			merged[key] = c.resolve(existing, endpoint)
+		}
+	}
+
+	endpoints := make([]types.ServiceEndpoint, 0, len(order))
+	for _, key := range order {
+		endpoints = append(endpoints, merged[key])
+	}
+
+	return endpoints, nil
+}
+
+// Watch starts one goroutine per sub-strategy and multiplexes their event channels into a single
+// output stream, stamping each event's Source with the strategy that produced it. Keeping one
+// goroutine per source (rather than a shared fan-in loop) guarantees events from the same source
+// are forwarded in the order that source emitted them.
+func (c *CompositeStrategy) Watch(ctx context.Context, filter *types.Filter) (<-chan types.ServiceEvent, error) {
+	out := make(chan types.ServiceEvent)
+	var wg sync.WaitGroup
+
+	for _, strategy := range c.strategies {
+		strategy := strategy
+
+		eventCh, err := strategy.Watch(ctx, filter)
+		if err != nil {
+			c.logger.Warn("Composite sub-strategy watch failed to start", zap.String("source", strategy.Name()), zap.Error(err))
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case event, ok := <-eventCh:
+					if !ok {
+						return
+					}
+					event.Source = strategy.Name()
+					select {
+					case out <- event:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
+
+// resolve decides which of two endpoints sharing a dedupe key to keep, per c.policy.
+func (c *CompositeStrategy) resolve(existing, incoming types.ServiceEndpoint) types.ServiceEndpoint {
+	switch c.policy {
+	case PreferNewest:
+		return incoming
+	case Union:
+		return unionEndpoints(existing, incoming)
+	default: // PreferFirst
+		return existing
+	}
+}
+
+// unionEndpoints merges b's Tags, MetaLabels, and Ports into a, preferring a's value on a
+// tag/label key collision.
+func unionEndpoints(a, b types.ServiceEndpoint) types.ServiceEndpoint {
+	merged := a
+
+	if len(b.Tags) > 0 {
+		merged.Tags = mergeStringMaps(a.Tags, b.Tags)
+	}
+	if len(b.MetaLabels) > 0 {
+		merged.MetaLabels = mergeStringMaps(a.MetaLabels, b.MetaLabels)
+	}
+
+	seenPorts := make(map[string]bool, len(a.Ports))
+	for _, port := range a.Ports {
+		seenPorts[portKey(port)] = true
+	}
+	for _, port := range b.Ports {
+		if !seenPorts[portKey(port)] {
+			merged.Ports = append(merged.Ports, port)
+			seenPorts[portKey(port)] = true
+		}
+	}
+
+	return merged
+}
+
+func mergeStringMaps(a, b map[string]string) map[string]string {
+	merged := make(map[string]string, len(a)+len(b))
+	for k, v := range b {
+		merged[k] = v
+	}
+	for k, v := range a {
+		merged[k] = v
+	}
+	return merged
+}
+
+func portKey(port types.ServicePort) string {
+	return fmt.Sprintf("%s:%d/%s", port.Name, port.Port, port.Protocol)
+}
+
+// dedupeKey derives a stable cross-source identity for endpoint from its name, address, port
+// set, and version, so the same logical service discovered by two different strategies (e.g.
+// Kubernetes and Docker Swarm) merges into one entry.
+func dedupeKey(endpoint types.ServiceEndpoint) string {
+	ports := make([]string, len(endpoint.Ports))
+	for i, port := range endpoint.Ports {
+		ports[i] = portKey(port)
+	}
+	sort.Strings(ports)
+
+	return strings.Join([]string{endpoint.Name, endpoint.Address, strings.Join(ports, ","), endpoint.Version}, "|")
+}
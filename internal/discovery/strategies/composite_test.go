@@ -0,0 +1,108 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/goletan/services-library/shared/types"
+)
+
+func TestCompositeStrategy_Resolve(t *testing.T) {
+	existing := types.ServiceEndpoint{
+		Name:       "svc",
+		Address:    "10.0.0.1",
+		Tags:       map[string]string{"source": "kubernetes"},
+		MetaLabels: map[string]string{"__meta_kubernetes_service_namespace": "default"},
+	}
+	incoming := types.ServiceEndpoint{
+		Name:       "svc",
+		Address:    "10.0.0.1",
+		Tags:       map[string]string{"source": "docker_swarm", "region": "us-east"},
+		MetaLabels: map[string]string{"__meta_docker_service_id": "abc123"},
+	}
+
+	tests := []struct {
+		name   string
+		policy MergePolicy
+		want   types.ServiceEndpoint
+	}{
+		{name: "prefer_first keeps existing", policy: PreferFirst, want: existing},
+		{name: "empty policy defaults to prefer_first behavior", policy: "", want: existing},
+		{name: "prefer_newest keeps incoming", policy: PreferNewest, want: incoming},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CompositeStrategy{policy: tt.policy}
+			got := c.resolve(existing, incoming)
+			if got.Tags["source"] != tt.want.Tags["source"] {
+				t.Errorf("resolve() = %+v, want source tag %q", got, tt.want.Tags["source"])
+			}
+		})
+	}
+
+	t.Run("union merges tags/meta-labels/ports, preferring existing on collision", func(t *testing.T) {
+		c := &CompositeStrategy{policy: Union}
+		got := c.resolve(existing, incoming)
+
+		if got.Tags["source"] != "kubernetes" {
+			t.Errorf("expected union to prefer existing's tag on collision, got %q", got.Tags["source"])
+		}
+		if got.Tags["region"] != "us-east" {
+			t.Errorf("expected union to pick up incoming's non-colliding tag, got %+v", got.Tags)
+		}
+		if got.MetaLabels["__meta_kubernetes_service_namespace"] != "default" {
+			t.Errorf("expected union to keep existing's meta-label, got %+v", got.MetaLabels)
+		}
+		if got.MetaLabels["__meta_docker_service_id"] != "abc123" {
+			t.Errorf("expected union to pick up incoming's meta-label, got %+v", got.MetaLabels)
+		}
+	})
+}
+
+func TestUnionEndpoints_Ports(t *testing.T) {
+	a := types.ServiceEndpoint{
+		Ports: []types.ServicePort{{Name: "http", Port: 80, Protocol: "TCP"}},
+	}
+	b := types.ServiceEndpoint{
+		Ports: []types.ServicePort{
+			{Name: "http", Port: 80, Protocol: "TCP"}, // duplicate, should not be added twice
+			{Name: "grpc", Port: 9090, Protocol: "TCP"},
+		},
+	}
+
+	merged := unionEndpoints(a, b)
+
+	if len(merged.Ports) != 2 {
+		t.Fatalf("expected 2 deduped ports, got %d: %+v", len(merged.Ports), merged.Ports)
+	}
+}
+
+func TestDedupeKey(t *testing.T) {
+	a := types.ServiceEndpoint{
+		Name:    "svc",
+		Address: "10.0.0.1",
+		Version: "1.0.0",
+		Ports: []types.ServicePort{
+			{Name: "grpc", Port: 9090, Protocol: "TCP"},
+			{Name: "http", Port: 80, Protocol: "TCP"},
+		},
+	}
+	b := types.ServiceEndpoint{
+		Name:    "svc",
+		Address: "10.0.0.1",
+		Version: "1.0.0",
+		Ports: []types.ServicePort{
+			{Name: "http", Port: 80, Protocol: "TCP"},
+			{Name: "grpc", Port: 9090, Protocol: "TCP"},
+		},
+	}
+
+	if dedupeKey(a) != dedupeKey(b) {
+		t.Errorf("expected dedupeKey to be order-independent over ports, got %q vs %q", dedupeKey(a), dedupeKey(b))
+	}
+
+	c := types.ServiceEndpoint{Name: "svc", Address: "10.0.0.2", Version: "1.0.0"}
+	if dedupeKey(a) == dedupeKey(c) {
+		t.Errorf("expected endpoints with different addresses to have different dedupe keys")
+	}
+}
@@ -0,0 +1,349 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/shared/types"
+	consulapi "github.com/hashicorp/consul/api"
+	"go.uber.org/zap"
+)
+
+const (
+	consulWatchTimeout = 5 * time.Minute
+	consulMinBackoff   = 1 * time.Second
+	consulMaxBackoff   = 30 * time.Second
+)
+
+// ConsulDiscovery discovers services-library from a HashiCorp Consul catalog.
+type ConsulDiscovery struct {
+	logger *logger.ZapLogger
+	config types.ConsulConfig
+	client *consulapi.Client
+}
+
+// NewConsulStrategy creates a new Consul discovery strategy.
+func NewConsulStrategy(log *logger.ZapLogger, consulConfig types.ConsulConfig) (*ConsulDiscovery, error) {
+	clientConfig := consulapi.DefaultConfig()
+	if consulConfig.Address != "" {
+		clientConfig.Address = consulConfig.Address
+	}
+	if consulConfig.Datacenter != "" {
+		clientConfig.Datacenter = consulConfig.Datacenter
+	}
+	if consulConfig.Token != "" {
+		clientConfig.Token = consulConfig.Token
+	}
+	if consulConfig.TLS != (types.ConsulTLSConfig{}) {
+		clientConfig.Scheme = "https"
+		clientConfig.TLSConfig = consulapi.TLSConfig{
+			CAFile:             consulConfig.TLS.CAFile,
+			CertFile:           consulConfig.TLS.CertFile,
+			KeyFile:            consulConfig.TLS.KeyFile,
+			InsecureSkipVerify: consulConfig.TLS.InsecureSkipVerify,
+		}
+	}
+
+	client, err := consulapi.NewClient(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consul client: %w", err)
+	}
+
+	return &ConsulDiscovery{
+		logger: log,
+		config: consulConfig,
+		client: client,
+	}, nil
+}
+
+func (cd *ConsulDiscovery) Name() string {
+	return "consul"
+}
+
+// Discover returns endpoints for the configured service, or, when no service is configured,
+// every service registered in the catalog that passes filter.
+func (cd *ConsulDiscovery) Discover(ctx context.Context, filter *types.Filter) ([]types.ServiceEndpoint, error) {
+	if cd.config.Service != "" {
+		return cd.discoverService(ctx, cd.config.Service, filter)
+	}
+
+	names, _, err := cd.catalogServiceNames(ctx, 0)
+	if err != nil {
+		cd.logger.Error("Failed to list Consul catalog services", zap.Error(err))
+		return nil, err
+	}
+
+	var endpoints []types.ServiceEndpoint
+	for name := range names {
+		serviceEndpoints, err := cd.discoverService(ctx, name, filter)
+		if err != nil {
+			cd.logger.Warn("Failed to query Consul health service, skipping", zap.String("service", name), zap.Error(err))
+			continue
+		}
+		endpoints = append(endpoints, serviceEndpoints...)
+	}
+
+	return endpoints, nil
+}
+
+// discoverService queries the health-checked instances of a single named service.
+func (cd *ConsulDiscovery) discoverService(ctx context.Context, name string, filter *types.Filter) ([]types.ServiceEndpoint, error) {
+	cd.logger.Info("Discovering services in Consul", zap.String("service", name))
+
+	entries, _, err := cd.client.Health().Service(name, cd.config.Tag, cd.config.PassingOnly, cd.queryOptions(ctx, 0))
+	if err != nil {
+		cd.logger.Error("Failed to query Consul health service", zap.String("service", name), zap.Error(err))
+		return nil, err
+	}
+
+	var endpoints []types.ServiceEndpoint
+	for _, entry := range entries {
+		endpoint := consulEntryToEndpoint(entry)
+		if !isDiscoverable(endpoint.Tags, filter) {
+			continue
+		}
+
+		endpoints = append(endpoints, endpoint)
+		cd.logger.Info("Added service to the list of discovered services", zap.String("name", endpoint.Name))
+	}
+
+	return endpoints, nil
+}
+
+// catalogServiceNames lists the distinct service names registered in the catalog via a
+// (possibly blocking) query, filtered to those that carry the configured tag, if any.
+func (cd *ConsulDiscovery) catalogServiceNames(ctx context.Context, waitIndex uint64) (map[string]struct{}, *consulapi.QueryMeta, error) {
+	services, meta, err := cd.client.Catalog().Services(cd.queryOptions(ctx, waitIndex))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make(map[string]struct{}, len(services))
+	for name, tags := range services {
+		if cd.config.Tag != "" && !containsTag(tags, cd.config.Tag) {
+			continue
+		}
+		names[name] = struct{}{}
+	}
+
+	return names, meta, nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch uses Consul blocking queries to stream ADDED/MODIFIED/DELETED events. When a specific
+// service is configured it watches that service alone; otherwise it blocking-queries the catalog
+// for the set of registered services and maintains one per-service watch goroutine per name,
+// starting and stopping them as services come and go.
+func (cd *ConsulDiscovery) Watch(ctx context.Context, filter *types.Filter) (<-chan types.ServiceEvent, error) {
+	eventsChan := make(chan types.ServiceEvent)
+
+	if cd.config.Service != "" {
+		go func() {
+			defer close(eventsChan)
+			cd.watchService(ctx, cd.config.Service, filter, eventsChan)
+		}()
+		return eventsChan, nil
+	}
+
+	go cd.watchCatalog(ctx, filter, eventsChan)
+	return eventsChan, nil
+}
+
+// watchCatalog blocking-queries the catalog's service list, spawning a watchService goroutine
+// for each newly registered name and cancelling it once the name disappears from the catalog.
+func (cd *ConsulDiscovery) watchCatalog(ctx context.Context, filter *types.Filter, eventsChan chan<- types.ServiceEvent) {
+	defer close(eventsChan)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	active := make(map[string]context.CancelFunc)
+	defer func() {
+		for _, cancel := range active {
+			cancel()
+		}
+	}()
+
+	var waitIndex uint64
+	backoff := consulMinBackoff
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		names, meta, err := cd.catalogServiceNames(ctx, waitIndex)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			cd.logger.Warn("Consul catalog blocking query failed, backing off", zap.Error(err), zap.Duration("backoff", backoff))
+			select {
+			case <-time.After(Jitter(backoff)):
+			case <-ctx.Done():
+				return
+			}
+			backoff = NextBackoff(backoff, consulMaxBackoff)
+			continue
+		}
+		backoff = consulMinBackoff
+
+		if meta.LastIndex < waitIndex {
+			waitIndex = 0
+		} else {
+			waitIndex = meta.LastIndex
+		}
+
+		for name := range names {
+			if _, watching := active[name]; watching {
+				continue
+			}
+
+			watchCtx, cancel := context.WithCancel(ctx)
+			active[name] = cancel
+
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				cd.watchService(watchCtx, name, filter, eventsChan)
+			}(name)
+		}
+
+		for name, cancel := range active {
+			if _, stillPresent := names[name]; !stillPresent {
+				cancel()
+				delete(active, name)
+			}
+		}
+	}
+}
+
+// watchService blocking-queries a single named service's health entries, emitting
+// ADDED/MODIFIED/DELETED events on diff until ctx is cancelled.
+func (cd *ConsulDiscovery) watchService(ctx context.Context, name string, filter *types.Filter, eventsChan chan<- types.ServiceEvent) {
+	var waitIndex uint64
+	backoff := consulMinBackoff
+	previous := make(map[string]types.ServiceEndpoint)
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		entries, meta, err := cd.client.Health().Service(name, cd.config.Tag, cd.config.PassingOnly, cd.queryOptions(ctx, waitIndex))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			cd.logger.Warn("Consul blocking query failed, backing off", zap.String("service", name), zap.Error(err), zap.Duration("backoff", backoff))
+			select {
+			case <-time.After(Jitter(backoff)):
+			case <-ctx.Done():
+				return
+			}
+			backoff = NextBackoff(backoff, consulMaxBackoff)
+			continue
+		}
+		backoff = consulMinBackoff
+
+		// LastIndex can go backwards if Consul's Raft log was compacted; reset in that case.
+		if meta.LastIndex < waitIndex {
+			waitIndex = 0
+		} else {
+			waitIndex = meta.LastIndex
+		}
+
+		current := make(map[string]types.ServiceEndpoint, len(entries))
+		for _, entry := range entries {
+			endpoint := consulEntryToEndpoint(entry)
+			if !isDiscoverable(endpoint.Tags, filter) {
+				continue
+			}
+			current[entry.Service.ID] = endpoint
+		}
+
+		for id, endpoint := range current {
+			prevEndpoint, existed := previous[id]
+			switch {
+			case !existed:
+				if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "ADDED", Service: endpoint}) {
+					return
+				}
+			case !EndpointsEqual(prevEndpoint, endpoint):
+				if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "MODIFIED", Service: endpoint}) {
+					return
+				}
+			}
+		}
+		for id, endpoint := range previous {
+			if _, stillPresent := current[id]; !stillPresent {
+				if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "DELETED", Service: endpoint}) {
+					return
+				}
+			}
+		}
+
+		previous = current
+	}
+}
+
+func (cd *ConsulDiscovery) queryOptions(ctx context.Context, waitIndex uint64) *consulapi.QueryOptions {
+	opts := &consulapi.QueryOptions{
+		Datacenter: cd.config.Datacenter,
+		AllowStale: cd.config.AllowStale,
+		NodeMeta:   cd.config.NodeMeta,
+	}
+	if waitIndex > 0 {
+		opts.WaitIndex = waitIndex
+		opts.WaitTime = consulWatchTimeout
+	}
+
+	return opts.WithContext(ctx)
+}
+
+// consulEntryToEndpoint maps a Consul health entry to a ServiceEndpoint, preferring the
+// service-level address and falling back to the node address when it is unset.
+func consulEntryToEndpoint(entry *consulapi.ServiceEntry) types.ServiceEndpoint {
+	address := entry.Service.Address
+	if address == "" {
+		address = entry.Node.Address
+	}
+
+	tags := make(map[string]string, len(entry.Service.Tags)+len(entry.Service.Meta))
+	for _, tag := range entry.Service.Tags {
+		tags[tag] = "true"
+	}
+	for key, value := range entry.Service.Meta {
+		tags[key] = value
+	}
+
+	return types.ServiceEndpoint{
+		Name:    entry.Service.Service,
+		Address: address,
+		Ports:   []types.ServicePort{{Name: entry.Service.Service, Port: entry.Service.Port, Protocol: "TCP"}},
+		Tags:    tags,
+	}
+}
+
+// sendEvent forwards an event to ch, returning false if ctx is cancelled first.
+func sendEvent(ctx context.Context, ch chan<- types.ServiceEvent, event types.ServiceEvent) bool {
+	select {
+	case ch <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
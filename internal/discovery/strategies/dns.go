@@ -2,116 +2,213 @@ package strategies
 
 import (
 	"context"
-	"github.com/goletan/logger-library/pkg"
-	"github.com/goletan/services-library/shared/types"
-	"go.uber.org/zap"
+	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
+
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/shared/types"
+	"go.uber.org/zap"
 )
 
+const defaultDNSRefreshInterval = 10 * time.Second
+
+// DNSDiscovery discovers services-library via DNS SRV records, resolving each SRV target's
+// A/AAAA records into one endpoint per address.
 type DNSDiscovery struct {
-	logger    *logger.ZapLogger
-	namespace string
+	logger   *logger.ZapLogger
+	resolver *net.Resolver
+	domain   string
+	service  string
+	proto    string
+	interval time.Duration
 }
 
-func NewDNSStrategy(logger *logger.ZapLogger, namespace string) *DNSDiscovery {
+// NewDNSStrategy creates a new DNS SRV discovery strategy. namespace is the SRV name to query
+// directly (e.g. "_grpc._tcp.example.com"); call WithServiceProto to instead compose the query
+// from a separate service/proto pair.
+func NewDNSStrategy(log *logger.ZapLogger, namespace string) *DNSDiscovery {
 	return &DNSDiscovery{
-		logger:    logger,
-		namespace: namespace,
+		logger:   log,
+		resolver: net.DefaultResolver,
+		domain:   namespace,
+		interval: defaultDNSRefreshInterval,
 	}
 }
 
+// WithServiceProto configures the strategy to resolve "_service._proto.domain" via the
+// standard library's service/proto SRV composition instead of treating namespace as a literal name.
+func (d *DNSDiscovery) WithServiceProto(service, proto string) *DNSDiscovery {
+	d.service = service
+	d.proto = proto
+	return d
+}
+
+// WithRefreshInterval overrides the default polling interval used by Watch.
+func (d *DNSDiscovery) WithRefreshInterval(interval time.Duration) *DNSDiscovery {
+	if interval > 0 {
+		d.interval = interval
+	}
+	return d
+}
+
 func (d *DNSDiscovery) Name() string {
 	return "dns"
 }
 
 func (d *DNSDiscovery) Discover(ctx context.Context, filter *types.Filter) ([]types.ServiceEndpoint, error) {
-	records, err := net.LookupTXT(d.namespace)
+	endpoints, err := d.resolve(ctx)
 	if err != nil {
-		d.logger.Warn("DNS lookup failed", zap.Error(err))
+		d.logger.Warn("DNS SRV lookup failed", zap.String("domain", d.domain), zap.Error(err))
 		return nil, err
 	}
 
-	var endpoints []types.ServiceEndpoint
-	for _, record := range records {
-		endpoint := parseTXTRecord(record)
-
-		// Apply filters
-		if !MatchTags(endpoint.Tags, filter.Tags) {
-			continue
+	var filtered []types.ServiceEndpoint
+	for _, endpoint := range endpoints {
+		if MatchTags(endpoint.Tags, filter.Tags) {
+			filtered = append(filtered, endpoint)
 		}
-
-		endpoints = append(endpoints, endpoint)
 	}
 
-	return endpoints, nil
+	return filtered, nil
 }
 
 func (d *DNSDiscovery) Watch(ctx context.Context, filter *types.Filter) (<-chan types.ServiceEvent, error) {
-	serviceEventCh := make(chan types.ServiceEvent)
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
+	eventsChan := make(chan types.ServiceEvent)
+	ticker := time.NewTicker(d.interval)
+
+	previous := make(map[string]types.ServiceEndpoint)
+	poll := func() bool {
+		endpoints, err := d.resolve(ctx)
+		if err != nil {
+			d.logger.Warn("DNS SRV lookup failed", zap.String("domain", d.domain), zap.Error(err))
+			return true
+		}
+
+		current := make(map[string]types.ServiceEndpoint, len(endpoints))
+		for _, endpoint := range endpoints {
+			if !MatchTags(endpoint.Tags, filter.Tags) {
+				continue
+			}
+			current[endpoint.Name+"/"+endpoint.Address] = endpoint
+		}
 
-	prevRecords := make(map[string]types.ServiceEndpoint)
+		for key, endpoint := range current {
+			prevEndpoint, existed := previous[key]
+			switch {
+			case !existed:
+				if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "ADDED", Service: endpoint}) {
+					return false
+				}
+			case !EndpointsEqual(prevEndpoint, endpoint):
+				if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "MODIFIED", Service: endpoint}) {
+					return false
+				}
+			}
+		}
+		for key, endpoint := range previous {
+			if _, stillPresent := current[key]; !stillPresent {
+				if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "DELETED", Service: endpoint}) {
+					return false
+				}
+			}
+		}
+
+		previous = current
+		return true
+	}
 
 	go func() {
-		defer close(serviceEventCh)
+		defer ticker.Stop()
+		defer close(eventsChan)
+
+		if !poll() {
+			return
+		}
+
 		for {
 			select {
 			case <-ctx.Done():
 				d.logger.Info("Stopping DNS discovery watcher...")
 				return
 			case <-ticker.C:
-				records, err := net.LookupTXT(d.namespace)
-				if err != nil {
-					d.logger.Warn("DNS lookup failed", zap.Error(err))
-					continue
+				if !poll() {
+					return
 				}
+			}
+		}
+	}()
 
-				currentRecords := make(map[string]types.ServiceEndpoint)
-				for _, record := range records {
-					endpoint := parseTXTRecord(record)
+	return eventsChan, nil
+}
 
-					if !MatchTags(endpoint.Tags, filter.Tags) {
-						continue
-					}
+// resolve performs the SRV lookup and resolves each target to its A/AAAA addresses, optionally
+// enriching tags from a parallel TXT lookup on the target name.
+func (d *DNSDiscovery) resolve(ctx context.Context) ([]types.ServiceEndpoint, error) {
+	_, srvRecords, err := d.resolver.LookupSRV(ctx, d.service, d.proto, d.domain)
+	if err != nil {
+		return nil, fmt.Errorf("srv lookup failed for %s: %w", d.domain, err)
+	}
 
-					currentRecords[record] = endpoint
-					if _, seen := prevRecords[record]; !seen {
-						serviceEventCh <- types.ServiceEvent{Type: "ADDED", Service: endpoint}
-					}
-				}
+	var endpoints []types.ServiceEndpoint
+	for _, srv := range srvRecords {
+		target := strings.TrimSuffix(srv.Target, ".")
 
-				for record, endpoint := range prevRecords {
-					if _, stillPresent := currentRecords[record]; !stillPresent {
-						serviceEventCh <- types.ServiceEvent{Type: "DELETED", Service: endpoint}
-					}
-				}
+		addrs, err := d.resolver.LookupIPAddr(ctx, target)
+		if err != nil {
+			d.logger.Warn("Failed to resolve SRV target", zap.String("target", target), zap.Error(err))
+			continue
+		}
 
-				prevRecords = currentRecords
-			}
+		tags := d.lookupMetadata(ctx, target)
+		metaLabels := map[string]string{
+			"__meta_dns_srv_target":   target,
+			"__meta_dns_srv_priority": strconv.Itoa(int(srv.Priority)),
+			"__meta_dns_srv_weight":   strconv.Itoa(int(srv.Weight)),
 		}
-	}()
 
-	return serviceEventCh, nil
+		for _, addr := range addrs {
+			endpoints = append(endpoints, types.ServiceEndpoint{
+				Name:       target,
+				Address:    formatHost(addr.IP),
+				Ports:      []types.ServicePort{{Name: "default", Port: int(srv.Port), Protocol: "TCP"}},
+				Tags:       tags,
+				MetaLabels: metaLabels,
+				Version:    "1.0.0",
+			})
+		}
+	}
+
+	return endpoints, nil
 }
 
-func parseTXTRecord(record string) types.ServiceEndpoint {
+// lookupMetadata issues a best-effort TXT lookup on target and merges any "key=value" pairs
+// found into a tag map; a failed or empty lookup simply yields no extra tags.
+func (d *DNSDiscovery) lookupMetadata(ctx context.Context, target string) map[string]string {
 	tags := make(map[string]string)
-	parts := strings.Split(record, ",")
-	for _, part := range parts {
-		kv := strings.SplitN(part, "=", 2)
-		if len(kv) == 2 {
-			tags[kv[0]] = kv[1]
-		}
+
+	records, err := d.resolver.LookupTXT(ctx, target)
+	if err != nil {
+		return tags
 	}
 
-	return types.ServiceEndpoint{
-		Name:    "http-service",
-		Address: "10.0.0.1",
-		Ports:   []types.ServicePort{{Name: "http", Port: 8080, Protocol: "TCP"}},
-		Tags:    tags,
-		Version: "1.0.0",
+	for _, record := range records {
+		for _, part := range strings.Split(record, ",") {
+			kv := strings.SplitN(part, "=", 2)
+			if len(kv) == 2 {
+				tags[kv[0]] = kv[1]
+			}
+		}
 	}
+
+	return tags
+}
+
+// formatHost returns ip's string form, unbracketed even for IPv6 -- ServiceEndpoint.Address is a
+// bare host, not a host:port fragment. Callers composing "host:port" strings must bracket IPv6
+// themselves (net.JoinHostPort does this correctly).
+func formatHost(ip net.IP) string {
+	return ip.String()
 }
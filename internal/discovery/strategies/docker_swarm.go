@@ -4,12 +4,24 @@ import (
 	"context"
 	"fmt"
 	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/client"
 	logger "github.com/goletan/logger-library/pkg"
 	"github.com/goletan/services-library/shared/types"
 	"go.uber.org/zap"
 	"strings"
+	"time"
+)
+
+const (
+	// swarmResyncInterval bounds how long Watch can go without a full re-list of swarm services,
+	// as a safety net for events silently dropped or missed on the Docker events stream.
+	swarmResyncInterval = 60 * time.Second
+	swarmMinBackoff     = 1 * time.Second
+	swarmMaxBackoff     = 30 * time.Second
 )
 
 type DockerSwarmStrategy struct {
@@ -33,14 +45,164 @@ func (d *DockerSwarmStrategy) Name() string {
 func (d *DockerSwarmStrategy) Discover(ctx context.Context, filter *types.Filter) ([]types.ServiceEndpoint, error) {
 	d.logger.Info("Using Docker Swarm strategy for service discovery", zap.String("swarmNetwork", d.swarmNetwork))
 
-	// Create Docker client
 	cli, err := client.NewClientWithOpts(client.FromEnv)
 	if err != nil {
 		d.logger.Error("Failed to create Docker client", zap.Error(err))
 		return nil, err
 	}
+	defer cli.Close()
+
+	endpoints, err := d.listEndpoints(ctx, cli, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(endpoints) == 0 {
+		d.logger.Warn("No services discovered in the target swarm network", zap.String("swarmNetwork", d.swarmNetwork))
+		return nil, fmt.Errorf("no services discovered in swarm network: %s", d.swarmNetwork)
+	}
+
+	// Log discovered endpoints
+	for _, endpoint := range endpoints {
+		d.logger.Info("Discovered Service Endpoint:",
+			zap.String("name", endpoint.Name),
+			zap.String("address", endpoint.Address))
+	}
+
+	return endpoints, nil
+}
+
+// Watch subscribes to the Docker events API for service create/update/remove events scoped to
+// the swarm and, on every such event, re-lists the swarm's services and diffs them against the
+// last known snapshot to emit ADDED/MODIFIED/DELETED events. Diffing the listing rather than
+// interpreting individual event payloads keeps Watch consistent with Discover and correct even
+// when events are coalesced or delivered out of order. A swarmResyncInterval ticker drives the
+// same rescan as a safety net for events silently dropped or missed on the event stream. If the
+// event stream itself ends or errors (daemon restart, network blip), it is re-established with
+// exponential backoff rather than ending Watch for good.
+func (d *DockerSwarmStrategy) Watch(ctx context.Context, filter *types.Filter) (<-chan types.ServiceEvent, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		d.logger.Error("Failed to create Docker client", zap.Error(err))
+		return nil, err
+	}
+
+	eventsChan := make(chan types.ServiceEvent)
+
+	go func() {
+		defer cli.Close()
+		defer close(eventsChan)
+
+		previous := make(map[string]types.ServiceEndpoint)
+
+		rescan := func() bool {
+			current, err := d.listEndpoints(ctx, cli, filter)
+			if err != nil {
+				d.logger.Warn("Failed to re-list Docker Swarm services, keeping last known endpoints", zap.Error(err))
+				return true
+			}
+
+			currentByName := make(map[string]types.ServiceEndpoint, len(current))
+			for _, endpoint := range current {
+				currentByName[endpoint.Name] = endpoint
+			}
+
+			for name, endpoint := range currentByName {
+				prevEndpoint, existed := previous[name]
+				switch {
+				case !existed:
+					if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "ADDED", Service: endpoint}) {
+						return false
+					}
+				case !EndpointsEqual(prevEndpoint, endpoint):
+					if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "MODIFIED", Service: endpoint}) {
+						return false
+					}
+				}
+			}
+			for name, endpoint := range previous {
+				if _, stillPresent := currentByName[name]; !stillPresent {
+					if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "DELETED", Service: endpoint}) {
+						return false
+					}
+				}
+			}
+
+			previous = currentByName
+			return true
+		}
+
+		backoff := swarmMinBackoff
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !d.watchEvents(ctx, cli, eventsChan, rescan) {
+				return
+			}
+
+			d.logger.Warn("Docker Swarm event stream ended, reconnecting", zap.Duration("backoff", backoff))
+			select {
+			case <-time.After(Jitter(backoff)):
+			case <-ctx.Done():
+				return
+			}
+			backoff = NextBackoff(backoff, swarmMaxBackoff)
+		}
+	}()
+
+	return eventsChan, nil
+}
+
+// watchEvents subscribes to the Docker events API and drives rescan on every service event,
+// stream error, and swarmResyncInterval tick, until either the stream ends (channel closed or an
+// error is received) or ctx is cancelled. It reports false when Watch should stop for good (ctx
+// cancelled, or rescan asked to stop because a send was abandoned), true when the caller should
+// reconnect.
+func (d *DockerSwarmStrategy) watchEvents(ctx context.Context, cli *client.Client, eventsChan chan<- types.ServiceEvent, rescan func() bool) bool {
+	eventFilter := filters.NewArgs(filters.Arg("type", string(events.ServiceEventType)))
+	msgs, errs := cli.Events(ctx, events.ListOptions{Filters: eventFilter})
+
+	if !rescan() {
+		return false
+	}
+
+	ticker := time.NewTicker(swarmResyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Stopping Docker Swarm watch...")
+			return false
+		case _, ok := <-msgs:
+			if !ok {
+				return true
+			}
+			if !rescan() {
+				return false
+			}
+		case err, ok := <-errs:
+			if ctx.Err() != nil {
+				return false
+			}
+			if !ok {
+				return true
+			}
+			d.logger.Warn("Docker Swarm event stream error, reconnecting", zap.Error(err))
+			return true
+		case <-ticker.C:
+			if !rescan() {
+				return false
+			}
+		}
+	}
+}
 
-	// Find the target network by swarmNetwork
+// listEndpoints lists the Swarm services attached to swarmNetwork and converts them to endpoints,
+// applying filter. It is shared by Discover and Watch's rescans.
+func (d *DockerSwarmStrategy) listEndpoints(ctx context.Context, cli *client.Client, filter *types.Filter) ([]types.ServiceEndpoint, error) {
 	var targetNetworkID string
 	networks, err := cli.NetworkList(ctx, network.ListOptions{})
 	if err != nil {
@@ -60,56 +222,60 @@ func (d *DockerSwarmStrategy) Discover(ctx context.Context, filter *types.Filter
 		return nil, fmt.Errorf("network not found: %s", d.swarmNetwork)
 	}
 
-	// List services in Swarm
 	services, err := cli.ServiceList(ctx, dockerTypes.ServiceListOptions{})
 	if err != nil {
 		d.logger.Error("Failed to list Docker Swarm services", zap.Error(err))
 		return nil, err
 	}
 
-	// Extract endpoints and apply filtering
 	var endpoints []types.ServiceEndpoint
 	for _, service := range services {
 		for _, vip := range service.Endpoint.VirtualIPs {
-			if vip.NetworkID == targetNetworkID {
-				endpoint := types.ServiceEndpoint{
-					Name:    service.Spec.Name,
-					Address: strings.Split(vip.Addr, "/")[0],
-				}
+			if vip.NetworkID != targetNetworkID {
+				continue
+			}
 
-				// Apply filters (if provided)
-				if filter != nil {
-					if !MatchLabels(service.Spec.Labels, filter.Labels) {
-						continue
-					}
-					if !MatchTags(service.Spec.Annotations.Labels, filter.Tags) {
-						continue
-					}
-				}
+			endpoint := types.ServiceEndpoint{
+				Name:       service.Spec.Name,
+				Address:    strings.Split(vip.Addr, "/")[0],
+				MetaLabels: dockerSwarmMetaLabels(&service),
+				Type:       d.Name(),
+			}
 
-				endpoints = append(endpoints, endpoint)
+			if filter != nil {
+				if !MatchLabels(service.Spec.Labels, filter.Labels) {
+					continue
+				}
+				if !MatchTags(service.Spec.Annotations.Labels, filter.Tags) {
+					continue
+				}
 			}
+
+			endpoints = append(endpoints, endpoint)
 		}
 	}
 
-	if len(endpoints) == 0 {
-		d.logger.Warn("No services discovered in the target swarm network", zap.String("swarmNetwork", d.swarmNetwork))
-		return nil, fmt.Errorf("no services discovered in swarm network: %s", d.swarmNetwork)
-	}
+	return endpoints, nil
+}
 
-	// Log discovered endpoints
-	for _, endpoint := range endpoints {
-		d.logger.Info("Discovered Service Endpoint:",
-			zap.String("name", endpoint.Name),
-			zap.String("address", endpoint.Address))
+// dockerSwarmMetaLabels builds "__meta_docker_*" labels from a swarm service's spec, so a
+// relabel pipeline can filter/rewrite endpoints by container/task label or annotation.
+func dockerSwarmMetaLabels(service *swarm.Service) map[string]string {
+	meta := map[string]string{
+		"__meta_docker_service_id": service.ID,
 	}
 
-	return endpoints, nil
-}
+	for key, value := range service.Spec.Labels {
+		meta["__meta_docker_service_label_"+key] = value
+	}
+	for key, value := range service.Spec.Annotations.Labels {
+		meta["__meta_docker_service_annotation_"+key] = value
+	}
+	if containerSpec := service.Spec.TaskTemplate.ContainerSpec; containerSpec != nil {
+		for key, value := range containerSpec.Labels {
+			meta["__meta_docker_container_label_"+key] = value
+		}
+	}
 
-// Watch watches for service changes in the Docker Swarm swarmNetwork.
-func (d *DockerSwarmStrategy) Watch(ctx context.Context, filter *types.Filter) (<-chan types.ServiceEvent, error) {
-	// TODO: Implement watch functionality for Docker Swarm services
-	d.logger.Info("Watching Docker Swarm services is not implemented yet", zap.String("swarmNetwork", d.swarmNetwork))
-	return nil, fmt.Errorf("watch functionality not implemented")
+	return meta
 }
@@ -0,0 +1,277 @@
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/internal/metrics"
+	"github.com/goletan/services-library/shared/types"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultFileRefreshInterval = 30 * time.Second
+
+// FileStrategy discovers services-library from static YAML/JSON target files, the same "file SD"
+// mechanism Prometheus-ecosystem tools use to integrate with custom inventory systems or tests.
+// Watch hot-reloads on fsnotify events, falling back to a periodic re-scan so changes on network
+// filesystems that don't deliver inotify events are still picked up.
+type FileStrategy struct {
+	logger   *logger.ZapLogger
+	metrics  *metrics.ServicesMetrics
+	globs    []string
+	interval time.Duration
+}
+
+// NewFileStrategy creates a new file-based discovery strategy. globs are glob patterns (e.g.
+// "configs/services/*.yaml") matched on every Discover/Watch re-scan.
+func NewFileStrategy(log *logger.ZapLogger, met *metrics.ServicesMetrics, globs []string, refreshInterval time.Duration) *FileStrategy {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultFileRefreshInterval
+	}
+
+	return &FileStrategy{
+		logger:   log,
+		metrics:  met,
+		globs:    globs,
+		interval: refreshInterval,
+	}
+}
+
+func (fs *FileStrategy) Name() string {
+	return "file"
+}
+
+func (fs *FileStrategy) Discover(ctx context.Context, filter *types.Filter) ([]types.ServiceEndpoint, error) {
+	var endpoints []types.ServiceEndpoint
+	for path, fileEndpoints := range fs.readAll() {
+		for _, endpoint := range fileEndpoints {
+			if !isDiscoverable(endpoint.Tags, filter) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+		fs.logger.Info("Read file SD target file", zap.String("path", path), zap.Int("count", len(fileEndpoints)))
+	}
+
+	return endpoints, nil
+}
+
+// Watch watches the parent directory of every configured glob for changes via fsnotify, re-reading
+// and diffing the changed file against its last known snapshot, and also re-scans every interval
+// as a safety net for missed filesystem events.
+func (fs *FileStrategy) Watch(ctx context.Context, filter *types.Filter) (<-chan types.ServiceEvent, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file SD watcher: %w", err)
+	}
+
+	for _, dir := range fs.watchDirs() {
+		if err := watcher.Add(dir); err != nil {
+			fs.logger.Warn("Failed to watch directory for file SD", zap.String("dir", dir), zap.Error(err))
+		}
+	}
+
+	eventsChan := make(chan types.ServiceEvent)
+	snapshots := make(map[string]map[string]types.ServiceEndpoint) // path -> endpointKey -> endpoint
+
+	rescan := func() bool {
+		current := fs.readAll()
+
+		for path, fileEndpoints := range current {
+			filtered := make(map[string]types.ServiceEndpoint, len(fileEndpoints))
+			for _, endpoint := range fileEndpoints {
+				if isDiscoverable(endpoint.Tags, filter) {
+					filtered[endpointKey(endpoint)] = endpoint
+				}
+			}
+
+			previous := snapshots[path]
+			for key, endpoint := range filtered {
+				prevEndpoint, existed := previous[key]
+				switch {
+				case !existed:
+					if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "ADDED", Service: endpoint}) {
+						return false
+					}
+				case !EndpointsEqual(prevEndpoint, endpoint):
+					if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "MODIFIED", Service: endpoint}) {
+						return false
+					}
+				}
+			}
+			for key, endpoint := range previous {
+				if _, stillPresent := filtered[key]; !stillPresent {
+					if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "DELETED", Service: endpoint}) {
+						return false
+					}
+				}
+			}
+
+			snapshots[path] = filtered
+		}
+
+		matched := fs.matchedPaths()
+		for path, previous := range snapshots {
+			if _, stillMatched := current[path]; stillMatched {
+				continue
+			}
+			if matched[path] {
+				// path still matches a configured glob; readAll just failed to parse it this
+				// pass (already logged/counted there), so keep serving its last good snapshot
+				// rather than flapping every endpoint in it to deleted.
+				continue
+			}
+			for _, endpoint := range previous {
+				if !sendEvent(ctx, eventsChan, types.ServiceEvent{Type: "DELETED", Service: endpoint}) {
+					return false
+				}
+			}
+			delete(snapshots, path)
+		}
+
+		return true
+	}
+
+	go func() {
+		defer watcher.Close()
+		defer close(eventsChan)
+
+		ticker := time.NewTicker(fs.interval)
+		defer ticker.Stop()
+
+		if !rescan() {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				fs.logger.Info("Stopping file SD watcher...")
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+					continue
+				}
+				if !rescan() {
+					return
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				fs.logger.Warn("File SD watcher error", zap.Error(err))
+			case <-ticker.C:
+				if !rescan() {
+					return
+				}
+			}
+		}
+	}()
+
+	return eventsChan, nil
+}
+
+// readAll expands every configured glob and parses each matched file, keyed by path. A malformed
+// file logs a warning, increments file_sd_read_errors_total, and is simply omitted from the
+// result for this pass. Watch's rescan diffs against its own last-known snapshot, so the
+// previously served endpoints for that path keep being reported rather than disappearing;
+// Discover keeps no snapshot, so its result has no endpoints for that path.
+func (fs *FileStrategy) readAll() map[string][]types.ServiceEndpoint {
+	results := make(map[string][]types.ServiceEndpoint)
+
+	for _, glob := range fs.globs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			fs.logger.Warn("Invalid file SD glob pattern", zap.String("glob", glob), zap.Error(err))
+			continue
+		}
+
+		for _, path := range matches {
+			endpoints, err := fs.readFile(path)
+			if err != nil {
+				fs.logger.Warn("Failed to read file SD target file, keeping last known endpoints", zap.String("path", path), zap.Error(err))
+				fs.metrics.ObserveFileSDReadError(path)
+				continue
+			}
+			results[path] = endpoints
+		}
+	}
+
+	return results
+}
+
+// readFile parses a single target file into endpoints, dispatching on file extension.
+func (fs *FileStrategy) readFile(path string) ([]types.ServiceEndpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var endpoints []types.ServiceEndpoint
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &endpoints); err != nil {
+			return nil, fmt.Errorf("failed to parse %s as JSON: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &endpoints); err != nil {
+			return nil, fmt.Errorf("failed to parse %s as YAML: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file SD extension for %s", path)
+	}
+
+	for i := range endpoints {
+		if endpoints[i].MetaLabels == nil {
+			endpoints[i].MetaLabels = make(map[string]string)
+		}
+		endpoints[i].MetaLabels["__meta_filepath"] = path
+	}
+
+	return endpoints, nil
+}
+
+// matchedPaths returns the set of paths currently matched by any configured glob, independent of
+// whether readFile was able to parse them. Used by rescan to distinguish "file still exists but
+// failed to parse this pass" from "file no longer matches any glob" (e.g. deleted or renamed).
+func (fs *FileStrategy) matchedPaths() map[string]bool {
+	matched := make(map[string]bool)
+	for _, glob := range fs.globs {
+		paths, err := filepath.Glob(glob)
+		if err != nil {
+			continue
+		}
+		for _, path := range paths {
+			matched[path] = true
+		}
+	}
+	return matched
+}
+
+// watchDirs returns the deduplicated set of parent directories across every configured glob,
+// since fsnotify watches directories rather than glob patterns.
+func (fs *FileStrategy) watchDirs() []string {
+	seen := make(map[string]bool)
+	var dirs []string
+
+	for _, glob := range fs.globs {
+		dir := filepath.Dir(glob)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
@@ -52,11 +52,14 @@ func (kd *KubernetesDiscovery) Discover(ctx context.Context, filter *types.Filte
 
 	var endpoints []types.ServiceEndpoint
 	for _, svc := range services.Items {
+		svc := svc
 		endpoint := types.ServiceEndpoint{
-			Name:    svc.Name,
-			Address: svc.Spec.ClusterIP,
-			Ports:   ConvertPorts(svc.Spec.Ports),
-			Tags:    svc.Labels,
+			Name:       svc.Name,
+			Address:    svc.Spec.ClusterIP,
+			Ports:      ConvertPorts(svc.Spec.Ports),
+			Tags:       svc.Labels,
+			MetaLabels: kubernetesMetaLabels(&svc),
+			Type:       kd.Name(),
 		}
 
 		if isDiscoverable(endpoint.Tags, filter) {
@@ -96,10 +99,12 @@ func (kd *KubernetesDiscovery) Watch(ctx context.Context, filter *types.Filter)
 		AddFunc: func(obj interface{}) {
 			svc := obj.(*v1.Service)
 			endpoint := types.ServiceEndpoint{
-				Name:    svc.Name,
-				Address: svc.Spec.ClusterIP,
-				Ports:   ConvertPorts(svc.Spec.Ports),
-				Tags:    svc.Labels,
+				Name:       svc.Name,
+				Address:    svc.Spec.ClusterIP,
+				Ports:      ConvertPorts(svc.Spec.Ports),
+				Tags:       svc.Labels,
+				MetaLabels: kubernetesMetaLabels(svc),
+				Type:       kd.Name(),
 			}
 
 			if MatchTags(endpoint.Tags, filter.Tags) {
@@ -109,10 +114,12 @@ func (kd *KubernetesDiscovery) Watch(ctx context.Context, filter *types.Filter)
 		UpdateFunc: func(_, newObj interface{}) {
 			svc := newObj.(*v1.Service)
 			endpoint := types.ServiceEndpoint{
-				Name:    svc.Name,
-				Address: svc.Spec.ClusterIP,
-				Ports:   ConvertPorts(svc.Spec.Ports),
-				Tags:    svc.Labels,
+				Name:       svc.Name,
+				Address:    svc.Spec.ClusterIP,
+				Ports:      ConvertPorts(svc.Spec.Ports),
+				Tags:       svc.Labels,
+				MetaLabels: kubernetesMetaLabels(svc),
+				Type:       kd.Name(),
 			}
 
 			if MatchTags(endpoint.Tags, filter.Tags) {
@@ -122,10 +129,12 @@ func (kd *KubernetesDiscovery) Watch(ctx context.Context, filter *types.Filter)
 		DeleteFunc: func(obj interface{}) {
 			svc := obj.(*v1.Service)
 			endpoint := types.ServiceEndpoint{
-				Name:    svc.Name,
-				Address: svc.Spec.ClusterIP,
-				Ports:   ConvertPorts(svc.Spec.Ports),
-				Tags:    svc.Labels,
+				Name:       svc.Name,
+				Address:    svc.Spec.ClusterIP,
+				Ports:      ConvertPorts(svc.Spec.Ports),
+				Tags:       svc.Labels,
+				MetaLabels: kubernetesMetaLabels(svc),
+				Type:       kd.Name(),
 			}
 
 			eventsChan <- types.ServiceEvent{Type: "DELETED", Service: endpoint}
@@ -143,3 +152,26 @@ func (kd *KubernetesDiscovery) Watch(ctx context.Context, filter *types.Filter)
 
 	return eventsChan, nil
 }
+
+// kubernetesMetaLabels builds the Prometheus-style "__meta_kubernetes_*" labels for svc, so a
+// relabel pipeline can filter/rewrite endpoints by namespace, service name, port name, or any
+// individual label/annotation.
+func kubernetesMetaLabels(svc *v1.Service) map[string]string {
+	meta := map[string]string{
+		"__meta_kubernetes_service_namespace": svc.Namespace,
+		"__meta_kubernetes_service_name":      svc.Name,
+	}
+
+	if len(svc.Spec.Ports) > 0 {
+		meta["__meta_kubernetes_service_port_name"] = svc.Spec.Ports[0].Name
+	}
+
+	for key, value := range svc.Labels {
+		meta["__meta_kubernetes_service_label_"+key] = value
+	}
+	for key, value := range svc.Annotations {
+		meta["__meta_kubernetes_service_annotation_"+key] = value
+	}
+
+	return meta
+}
@@ -1,6 +1,9 @@
 package strategies
 
 import (
+	"math/rand"
+	"time"
+
 	"github.com/goletan/services-library/shared/types"
 	v1 "k8s.io/api/core/v1"
 )
@@ -34,3 +37,41 @@ func MatchTags(serviceTags map[string]string, filterTags map[string]string) bool
 	}
 	return true
 }
+
+// NextBackoff doubles the current backoff, capped at max.
+func NextBackoff(current, max time.Duration) time.Duration {
+	next := current * 2
+	if next > max {
+		return max
+	}
+	return next
+}
+
+// Jitter returns d plus up to 20% random jitter, to avoid thundering-herd reconnects.
+func Jitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
+}
+
+// endpointKey derives the per-source snapshot key strategies use to diff a rescan against the
+// last known state of a single endpoint.
+func endpointKey(endpoint types.ServiceEndpoint) string {
+	return endpoint.Name + "/" + endpoint.Address
+}
+
+// EndpointsEqual reports whether two endpoints have the same address, ports, and tags.
+func EndpointsEqual(a, b types.ServiceEndpoint) bool {
+	if a.Address != b.Address || len(a.Ports) != len(b.Ports) || len(a.Tags) != len(b.Tags) {
+		return false
+	}
+	for i := range a.Ports {
+		if a.Ports[i] != b.Ports[i] {
+			return false
+		}
+	}
+	for key, value := range a.Tags {
+		if b.Tags[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -1,38 +1,174 @@
 package metrics
 
 import (
-	"github.com/goletan/observability/pkg"
+	observability "github.com/goletan/observability-library/pkg"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// ServicesMetrics tracks execution duration and discovery health for services-library. Its
+// collectors are registered against an injected prometheus.Registerer rather than the package
+// default, so embedding this library alongside another goletan component sharing the default
+// registry doesn't panic on double registration.
 type ServicesMetrics struct {
 	obs *observability.Observability
+
+	ServiceExecutionDuration *prometheus.HistogramVec
+	DiscoveryAttemptsTotal   *prometheus.CounterVec
+	DiscoveryEndpoints       *prometheus.GaugeVec
+	WatchEventsTotal         *prometheus.CounterVec
+	SDUpdatesDroppedTotal    prometheus.Counter
+	FileSDReadErrorsTotal    *prometheus.CounterVec
 }
 
-// ServiceExecutionDuration Metrics: Track services-library execution durations.
-var (
-	ServiceExecutionDuration = prometheus.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Namespace: "goletan",
-			Subsystem: "services-library",
-			Name:      "execution_duration_seconds",
-			Help:      "Tracks the duration of services-library execution.",
-		},
-		[]string{"service", "operation"},
-	)
-)
+// InitMetrics builds and registers ServicesMetrics. registerer is optional; when omitted or nil
+// it falls back to obs.MetricsRegistry, then to prometheus.DefaultRegisterer.
+func InitMetrics(obs *observability.Observability, registerer ...prometheus.Registerer) *ServicesMetrics {
+	reg := resolveRegisterer(obs, registerer...)
+
+	em := &ServicesMetrics{
+		obs: obs,
+		ServiceExecutionDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "goletan",
+				Subsystem: "services_library",
+				Name:      "execution_duration_seconds",
+				Help:      "Tracks the duration of services-library execution.",
+			},
+			[]string{"service", "operation"},
+		),
+		DiscoveryAttemptsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "goletan",
+				Subsystem: "services_library",
+				Name:      "discovery_attempts_total",
+				Help:      "Total discovery attempts, labeled by strategy and result (success/error).",
+			},
+			[]string{"strategy", "result"},
+		),
+		DiscoveryEndpoints: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: "goletan",
+				Subsystem: "services_library",
+				Name:      "discovery_endpoints",
+				Help:      "Number of endpoints currently known to each discovery strategy.",
+			},
+			[]string{"strategy"},
+		),
+		WatchEventsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "goletan",
+				Subsystem: "services_library",
+				Name:      "watch_events_total",
+				Help:      "Total watch events emitted, labeled by strategy and event type.",
+			},
+			[]string{"strategy", "type"},
+		),
+		SDUpdatesDroppedTotal: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: "goletan",
+				Subsystem: "services_library",
+				Name:      "sd_updates_dropped_total",
+				Help:      "Total discovery updates dropped because the consumer channel was full.",
+			},
+		),
+		FileSDReadErrorsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "goletan",
+				Subsystem: "services_library",
+				Name:      "file_sd_read_errors_total",
+				Help:      "Total errors reading or parsing a file SD target file, labeled by path.",
+			},
+			[]string{"path"},
+		),
+	}
 
-func InitMetrics(obs *observability.Observability) *ServicesMetrics {
-	metrics := &ServicesMetrics{obs: obs}
-	metrics.Register()
-	return metrics
+	em.ServiceExecutionDuration = registerHistogramVec(reg, em.ServiceExecutionDuration)
+	em.DiscoveryAttemptsTotal = registerCounterVec(reg, em.DiscoveryAttemptsTotal)
+	em.DiscoveryEndpoints = registerGaugeVec(reg, em.DiscoveryEndpoints)
+	em.WatchEventsTotal = registerCounterVec(reg, em.WatchEventsTotal)
+	em.SDUpdatesDroppedTotal = registerCounter(reg, em.SDUpdatesDroppedTotal)
+	em.FileSDReadErrorsTotal = registerCounterVec(reg, em.FileSDReadErrorsTotal)
+
+	return em
 }
 
-func (em *ServicesMetrics) Register() {
-	prometheus.MustRegister(ServiceExecutionDuration)
+// resolveRegisterer picks the first non-nil option in: the explicit registerer argument,
+// obs.MetricsRegistry, prometheus.DefaultRegisterer.
+func resolveRegisterer(obs *observability.Observability, registerer ...prometheus.Registerer) prometheus.Registerer {
+	if len(registerer) > 0 && registerer[0] != nil {
+		return registerer[0]
+	}
+	if obs != nil && obs.MetricsRegistry != nil {
+		return obs.MetricsRegistry
+	}
+	return prometheus.DefaultRegisterer
 }
 
 // ObserveExecution records the execution duration of a service operation.
 func (em *ServicesMetrics) ObserveExecution(service, operation string, duration float64) {
-	ServiceExecutionDuration.WithLabelValues(service, operation).Observe(duration)
+	em.ServiceExecutionDuration.WithLabelValues(service, operation).Observe(duration)
+}
+
+// ObserveDiscoveryAttempt records the outcome of a discovery attempt for strategy.
+func (em *ServicesMetrics) ObserveDiscoveryAttempt(strategy, result string) {
+	em.DiscoveryAttemptsTotal.WithLabelValues(strategy, result).Inc()
+}
+
+// SetDiscoveryEndpoints records the current number of endpoints known to strategy.
+func (em *ServicesMetrics) SetDiscoveryEndpoints(strategy string, count int) {
+	em.DiscoveryEndpoints.WithLabelValues(strategy).Set(float64(count))
+}
+
+// ObserveWatchEvent records a watch event of the given type emitted by strategy.
+func (em *ServicesMetrics) ObserveWatchEvent(strategy, eventType string) {
+	em.WatchEventsTotal.WithLabelValues(strategy, eventType).Inc()
+}
+
+// ObserveFileSDReadError records a failure to read or parse the file SD target file at path.
+func (em *ServicesMetrics) ObserveFileSDReadError(path string) {
+	em.FileSDReadErrorsTotal.WithLabelValues(path).Inc()
+}
+
+func registerHistogramVec(reg prometheus.Registerer, vec *prometheus.HistogramVec) *prometheus.HistogramVec {
+	if err := reg.Register(vec); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := are.ExistingCollector.(*prometheus.HistogramVec); ok {
+				return existing
+			}
+		}
+	}
+	return vec
+}
+
+func registerCounterVec(reg prometheus.Registerer, vec *prometheus.CounterVec) *prometheus.CounterVec {
+	if err := reg.Register(vec); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := are.ExistingCollector.(*prometheus.CounterVec); ok {
+				return existing
+			}
+		}
+	}
+	return vec
+}
+
+func registerGaugeVec(reg prometheus.Registerer, vec *prometheus.GaugeVec) *prometheus.GaugeVec {
+	if err := reg.Register(vec); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := are.ExistingCollector.(*prometheus.GaugeVec); ok {
+				return existing
+			}
+		}
+	}
+	return vec
+}
+
+func registerCounter(reg prometheus.Registerer, counter prometheus.Counter) prometheus.Counter {
+	if err := reg.Register(counter); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := are.ExistingCollector.(prometheus.Counter); ok {
+				return existing
+			}
+		}
+	}
+	return counter
 }
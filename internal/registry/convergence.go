@@ -0,0 +1,194 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/client"
+	"github.com/goletan/services-library/shared/types"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	defaultConvergenceTimeout      = 2 * time.Minute
+	defaultConvergencePollInterval = 2 * time.Second
+)
+
+// ConvergenceOptions configures StartAllAndWait.
+type ConvergenceOptions struct {
+	// ConvergenceTimeout bounds how long to wait for each service to converge after Start
+	// returns. Defaults to defaultConvergenceTimeout when zero.
+	ConvergenceTimeout time.Duration
+	// HealthCheck, when set, replaces the built-in Docker Swarm/Kubernetes convergence checks
+	// and is polled until it reports ready or ConvergenceTimeout elapses.
+	HealthCheck func(ctx context.Context, service types.Service) (bool, error)
+}
+
+// StartAllAndWait starts every registered service and blocks until each has converged, mirroring
+// the service-progress behavior of `docker stack deploy`: Docker Swarm services are polled via
+// TaskList until running tasks catch up with the desired count and none is stuck restarting,
+// and Kubernetes services are polled via their Endpoints until a ready address exists. Set
+// opts.HealthCheck to replace the backend-specific check with a caller-provided one. One tracer
+// span is recorded per service, with events marking the start/converged transitions.
+func (r *Registry) StartAllAndWait(ctx context.Context, opts ConvergenceOptions) error {
+	var operationErrors []error
+
+	r.cache.rangeAll(func(name string, service types.Service) {
+		_, span := r.obs.Tracer.Start(ctx, fmt.Sprintf("start-and-wait-service-%s", name))
+		defer span.End()
+
+		if err := service.Start(ctx); err != nil {
+			operationErrors = append(operationErrors, err)
+			r.obs.Logger.Error("Failed to start service", zap.String("service", name), zap.Error(err))
+			return
+		}
+		span.AddEvent("started")
+
+		if err := r.waitForConvergence(ctx, service, opts); err != nil {
+			operationErrors = append(operationErrors, err)
+			r.obs.Logger.Error("Service failed to converge", zap.String("service", name), zap.Error(err))
+			return
+		}
+		span.AddEvent("converged")
+	})
+
+	if len(operationErrors) > 0 {
+		return fmt.Errorf("failed to start and converge one or more services-library: %v", operationErrors)
+	}
+
+	return nil
+}
+
+// waitForConvergence polls check (or the backend-specific default) until service reports ready,
+// opts.ConvergenceTimeout elapses, or ctx is cancelled.
+func (r *Registry) waitForConvergence(ctx context.Context, service types.Service, opts ConvergenceOptions) error {
+	timeout := opts.ConvergenceTimeout
+	if timeout <= 0 {
+		timeout = defaultConvergenceTimeout
+	}
+
+	check := opts.HealthCheck
+	if check == nil {
+		check = defaultConvergenceCheck
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(defaultConvergencePollInterval)
+	defer ticker.Stop()
+
+	for {
+		ready, err := check(waitCtx, service)
+		if err != nil {
+			return fmt.Errorf("convergence check failed for service %s: %w", service.Name(), err)
+		}
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("service %s did not converge within %s: %w", service.Name(), timeout, waitCtx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// defaultConvergenceCheck dispatches to a backend-specific convergence check based on the
+// service's discovery type, treating any other type as already converged.
+func defaultConvergenceCheck(ctx context.Context, service types.Service) (bool, error) {
+	switch service.Type() {
+	case "docker_swarm":
+		return dockerSwarmConverged(ctx, service.Name())
+	case "kubernetes":
+		return kubernetesEndpointsReady(ctx, service)
+	default:
+		return true, nil
+	}
+}
+
+// dockerSwarmConverged reports whether the named Swarm service has as many running tasks as it
+// desires, with no task stuck in a failure-restart loop.
+func dockerSwarmConverged(ctx context.Context, name string) (bool, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return false, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	defer cli.Close()
+
+	svcs, err := cli.ServiceList(ctx, dockerTypes.ServiceListOptions{Filters: filters.NewArgs(filters.Arg("name", name))})
+	if err != nil {
+		return false, fmt.Errorf("failed to list Docker Swarm services: %w", err)
+	}
+	if len(svcs) == 0 {
+		return false, nil
+	}
+	svc := svcs[0]
+
+	var desired uint64
+	if svc.Spec.Mode.Replicated != nil && svc.Spec.Mode.Replicated.Replicas != nil {
+		desired = *svc.Spec.Mode.Replicated.Replicas
+	}
+
+	tasks, err := cli.TaskList(ctx, dockerTypes.TaskListOptions{Filters: filters.NewArgs(filters.Arg("service", svc.ID))})
+	if err != nil {
+		return false, fmt.Errorf("failed to list Docker Swarm tasks: %w", err)
+	}
+
+	var running uint64
+	for _, task := range tasks {
+		if task.Status.State == swarm.TaskStateRunning {
+			running++
+		}
+		if task.Status.State == swarm.TaskStateFailed && task.DesiredState == swarm.TaskStateRunning {
+			return false, fmt.Errorf("task %s for service %s is stuck restarting: %s", task.ID, name, task.Status.Err)
+		}
+	}
+
+	if desired == 0 {
+		return running > 0, nil
+	}
+	return running >= desired, nil
+}
+
+// kubernetesEndpointsReady reports whether the Kubernetes Endpoints object for service has at
+// least one subset with a ready address and a port.
+func kubernetesEndpointsReady(ctx context.Context, service types.Service) (bool, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return false, fmt.Errorf("failed to load in-cluster config: %w", err)
+	}
+
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return false, fmt.Errorf("failed to build Kubernetes client: %w", err)
+	}
+
+	namespace := "default"
+	if ks, ok := service.(*KubernetesService); ok && ks.Namespace != "" {
+		namespace = ks.Namespace
+	}
+
+	endpoints, err := clientSet.CoreV1().Endpoints(namespace).Get(ctx, service.Name(), metav1.GetOptions{})
+	if err != nil {
+		// Not found yet is "not converged", not a hard failure -- the endpoint controller may
+		// simply not have caught up with the service's tasks yet.
+		return false, nil
+	}
+
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 && len(subset.Ports) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
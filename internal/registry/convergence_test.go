@@ -0,0 +1,86 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goletan/services-library/shared/types"
+)
+
+func TestWaitForConvergence_ReadyImmediately(t *testing.T) {
+	r := &Registry{}
+	service := &Service{ServiceName: "svc"}
+
+	calls := 0
+	opts := ConvergenceOptions{
+		HealthCheck: func(ctx context.Context, service types.Service) (bool, error) {
+			calls++
+			return true, nil
+		},
+	}
+
+	if err := r.waitForConvergence(context.Background(), service, opts); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single poll when ready immediately, got %d", calls)
+	}
+}
+
+func TestWaitForConvergence_ReadyAfterPolls(t *testing.T) {
+	r := &Registry{}
+	service := &Service{ServiceName: "svc"}
+
+	calls := 0
+	opts := ConvergenceOptions{
+		// Three polls take two defaultConvergencePollInterval ticks (4s), so the timeout must exceed that.
+		ConvergenceTimeout: 10 * time.Second,
+		HealthCheck: func(ctx context.Context, service types.Service) (bool, error) {
+			calls++
+			return calls >= 3, nil
+		},
+	}
+
+	if err := r.waitForConvergence(context.Background(), service, opts); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected waitForConvergence to keep polling until ready, got %d calls", calls)
+	}
+}
+
+func TestWaitForConvergence_CheckError(t *testing.T) {
+	r := &Registry{}
+	service := &Service{ServiceName: "svc"}
+
+	wantErr := errors.New("health check boom")
+	opts := ConvergenceOptions{
+		HealthCheck: func(ctx context.Context, service types.Service) (bool, error) {
+			return false, wantErr
+		},
+	}
+
+	err := r.waitForConvergence(context.Background(), service, opts)
+	if err == nil || !errors.Is(err, wantErr) {
+		t.Fatalf("expected waitForConvergence to wrap the check error, got %v", err)
+	}
+}
+
+func TestWaitForConvergence_TimesOut(t *testing.T) {
+	r := &Registry{}
+	service := &Service{ServiceName: "svc"}
+
+	opts := ConvergenceOptions{
+		ConvergenceTimeout: 10 * time.Millisecond,
+		HealthCheck: func(ctx context.Context, service types.Service) (bool, error) {
+			return false, nil
+		},
+	}
+
+	err := r.waitForConvergence(context.Background(), service, opts)
+	if err == nil {
+		t.Fatal("expected waitForConvergence to return a timeout error")
+	}
+}
@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/shared/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// GRPCHealthService is a Service backed by a long-lived grpc.health.v1 Watch stream against the
+// endpoint's address, giving Start/Stop real behavior instead of the generic Service's no-ops.
+// endpoint.Tags["grpc_service"] selects which service name to watch; the empty string (the
+// default) watches the server's overall health.
+type GRPCHealthService struct {
+	logger   *logger.ZapLogger
+	endpoint types.ServiceEndpoint
+
+	conn   *grpc.ClientConn
+	cancel context.CancelFunc
+}
+
+// NewGRPCHealthService builds a GRPCHealthService for endpoint.
+func NewGRPCHealthService(log *logger.ZapLogger, endpoint types.ServiceEndpoint) *GRPCHealthService {
+	return &GRPCHealthService{logger: log, endpoint: endpoint}
+}
+
+func (g *GRPCHealthService) Name() string                { return g.endpoint.Name }
+func (g *GRPCHealthService) Type() string                { return "grpc" }
+func (g *GRPCHealthService) Address() string             { return g.endpoint.Address }
+func (g *GRPCHealthService) Metadata() map[string]string { return g.endpoint.Tags }
+
+func (g *GRPCHealthService) Initialize() error {
+	if g.endpoint.Address == "" {
+		return fmt.Errorf("grpc service %s has no address to dial", g.endpoint.Name)
+	}
+	return nil
+}
+
+// Start dials the service's address and keeps a grpc.health.v1 Watch stream open, reconnecting
+// on drop, until Stop is called.
+func (g *GRPCHealthService) Start(ctx context.Context) error {
+	conn, err := grpc.NewClient(g.target(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to dial grpc service %s: %w", g.endpoint.Name, err)
+	}
+	g.conn = conn
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	g.cancel = cancel
+
+	go g.watchHealth(watchCtx, healthpb.NewHealthClient(conn))
+
+	return nil
+}
+
+func (g *GRPCHealthService) watchHealth(ctx context.Context, client healthpb.HealthClient) {
+	for ctx.Err() == nil {
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: g.endpoint.Tags["grpc_service"]})
+		if err != nil {
+			g.logger.Warn("Failed to start grpc health watch", zap.String("service", g.endpoint.Name), zap.Error(err))
+			return
+		}
+
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				g.logger.Warn("grpc health watch stream ended, retrying", zap.String("service", g.endpoint.Name), zap.Error(err))
+				break
+			}
+
+			if resp.Status != healthpb.HealthCheckResponse_SERVING {
+				g.logger.Warn("grpc service reported unhealthy",
+					zap.String("service", g.endpoint.Name), zap.String("status", resp.Status.String()))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(defaultProbeInterval):
+		}
+	}
+}
+
+func (g *GRPCHealthService) Stop(ctx context.Context) error {
+	if g.cancel != nil {
+		g.cancel()
+	}
+	if g.conn != nil {
+		return g.conn.Close()
+	}
+	return nil
+}
+
+// target returns the endpoint's address, appending its first configured port if any.
+func (g *GRPCHealthService) target() string {
+	if len(g.endpoint.Ports) > 0 {
+		return net.JoinHostPort(g.endpoint.Address, strconv.Itoa(g.endpoint.Ports[0].Port))
+	}
+	return g.endpoint.Address
+}
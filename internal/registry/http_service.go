@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/shared/types"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHealthPath    = "/healthz"
+	defaultProbeInterval = 10 * time.Second
+	defaultProbeTimeout  = 5 * time.Second
+)
+
+// HTTPProbeService is a Service backed by periodic HTTP health-checks against
+// endpoint.Tags["health_path"] (defaulting to "/healthz"), giving Start/Stop real behavior
+// instead of the generic Service's no-ops.
+type HTTPProbeService struct {
+	logger     *logger.ZapLogger
+	endpoint   types.ServiceEndpoint
+	healthPath string
+	client     *http.Client
+
+	cancel context.CancelFunc
+}
+
+// NewHTTPProbeService builds an HTTPProbeService for endpoint.
+func NewHTTPProbeService(log *logger.ZapLogger, endpoint types.ServiceEndpoint) *HTTPProbeService {
+	healthPath := endpoint.Tags["health_path"]
+	if healthPath == "" {
+		healthPath = defaultHealthPath
+	}
+
+	return &HTTPProbeService{
+		logger:     log,
+		endpoint:   endpoint,
+		healthPath: healthPath,
+		client:     &http.Client{Timeout: defaultProbeTimeout},
+	}
+}
+
+func (h *HTTPProbeService) Name() string                { return h.endpoint.Name }
+func (h *HTTPProbeService) Type() string                { return "http" }
+func (h *HTTPProbeService) Address() string             { return h.endpoint.Address }
+func (h *HTTPProbeService) Metadata() map[string]string { return h.endpoint.Tags }
+
+func (h *HTTPProbeService) Initialize() error {
+	if h.endpoint.Address == "" {
+		return fmt.Errorf("http service %s has no address to probe", h.endpoint.Name)
+	}
+	return nil
+}
+
+// Start probes healthPath every defaultProbeInterval until Stop is called, logging rather than
+// failing on a probe error -- a transient health-check miss shouldn't tear the service down.
+func (h *HTTPProbeService) Start(ctx context.Context) error {
+	probeCtx, cancel := context.WithCancel(ctx)
+	h.cancel = cancel
+
+	url := fmt.Sprintf("http://%s%s", h.target(), h.healthPath)
+
+	go func() {
+		ticker := time.NewTicker(defaultProbeInterval)
+		defer ticker.Stop()
+
+		for {
+			h.probe(probeCtx, url)
+
+			select {
+			case <-probeCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (h *HTTPProbeService) probe(ctx context.Context, url string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		h.logger.Warn("Failed to build HTTP health probe request", zap.String("service", h.endpoint.Name), zap.Error(err))
+		return
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		h.logger.Warn("HTTP health probe failed", zap.String("service", h.endpoint.Name), zap.String("url", url), zap.Error(err))
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		h.logger.Warn("HTTP health probe returned an error status",
+			zap.String("service", h.endpoint.Name), zap.Int("status", resp.StatusCode))
+	}
+}
+
+func (h *HTTPProbeService) Stop(ctx context.Context) error {
+	if h.cancel != nil {
+		h.cancel()
+	}
+	return nil
+}
+
+// target returns the endpoint's address, appending its first configured port if any.
+func (h *HTTPProbeService) target() string {
+	if len(h.endpoint.Ports) > 0 {
+		return net.JoinHostPort(h.endpoint.Address, strconv.Itoa(h.endpoint.Ports[0].Port))
+	}
+	return h.endpoint.Address
+}
@@ -0,0 +1,133 @@
+package registry
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/goletan/services-library/shared/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// KubernetesService is a Kubernetes-backed Service that additionally implements
+// types.LoggableService by resolving the pods behind the service's selector and multiplexing
+// their clientSet.CoreV1().Pods(ns).GetLogs streams into a single channel.
+type KubernetesService struct {
+	ServiceName    string
+	ServiceAddress string
+	Namespace      string
+	Selector       map[string]string
+	Ports          []types.ServicePort
+	Tags           map[string]string
+}
+
+// NewKubernetesService builds a KubernetesService from a discovered endpoint, using its Tags as
+// the pod selector and the namespace meta-label the Kubernetes strategy attaches.
+func NewKubernetesService(endpoint types.ServiceEndpoint) *KubernetesService {
+	return &KubernetesService{
+		ServiceName:    endpoint.Name,
+		ServiceAddress: endpoint.Address,
+		Namespace:      endpoint.MetaLabels["__meta_kubernetes_service_namespace"],
+		Selector:       endpoint.Tags,
+		Ports:          endpoint.Ports,
+		Tags:           endpoint.Tags,
+	}
+}
+
+func (s *KubernetesService) Name() string                    { return s.ServiceName }
+func (s *KubernetesService) Type() string                    { return "kubernetes" }
+func (s *KubernetesService) Address() string                 { return s.ServiceAddress }
+func (s *KubernetesService) Metadata() map[string]string     { return s.Tags }
+func (s *KubernetesService) Initialize() error               { return nil }
+func (s *KubernetesService) Start(ctx context.Context) error { return nil }
+func (s *KubernetesService) Stop(ctx context.Context) error  { return nil }
+
+// Logs resolves every pod matching the service's selector and streams their logs concurrently
+// into a single channel, tagging each line with the producing pod's name as TaskID.
+func (s *KubernetesService) Logs(ctx context.Context, opts types.LogOptions) (<-chan types.LogEntry, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load in-cluster config: %w", err)
+	}
+
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Kubernetes client: %w", err)
+	}
+
+	namespace := s.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	pods, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(s.Selector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for service %s: %w", s.ServiceName, err)
+	}
+
+	entries := make(chan types.LogEntry)
+
+	var wg sync.WaitGroup
+	for _, pod := range pods.Items {
+		pod := pod
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			streamPodLogs(ctx, clientSet, namespace, pod.Name, opts, entries)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(entries)
+	}()
+
+	return entries, nil
+}
+
+func streamPodLogs(ctx context.Context, clientSet *kubernetes.Clientset, namespace, podName string, opts types.LogOptions, entries chan<- types.LogEntry) {
+	logOpts := &corev1.PodLogOptions{
+		Follow:     opts.Follow,
+		Timestamps: opts.Timestamps,
+	}
+	if opts.Tail > 0 {
+		tail := int64(opts.Tail)
+		logOpts.TailLines = &tail
+	}
+	if !opts.Since.IsZero() {
+		since := metav1.NewTime(opts.Since)
+		logOpts.SinceTime = &since
+	}
+
+	stream, err := clientSet.CoreV1().Pods(namespace).GetLogs(podName, logOpts).Stream(ctx)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		line := scanner.Text()
+		entry := types.LogEntry{Stream: "stdout", Line: line, TaskID: podName}
+
+		if opts.Timestamps {
+			if ts, rest, ok := splitLogTimestamp(line); ok {
+				entry.Timestamp = ts
+				entry.Line = rest
+			}
+		}
+
+		select {
+		case entries <- entry:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
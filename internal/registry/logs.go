@@ -0,0 +1,23 @@
+package registry
+
+import (
+	"strings"
+	"time"
+)
+
+// splitLogTimestamp splits a leading RFC3339Nano timestamp off line, as produced by backends
+// that were asked to prefix their log output with timestamps. It returns ok=false, leaving line
+// untouched, if no such prefix is present.
+func splitLogTimestamp(line string) (time.Time, string, bool) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) != 2 {
+		return time.Time{}, line, false
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, line, false
+	}
+
+	return ts, parts[1], true
+}
@@ -13,24 +13,79 @@ import (
 // ServiceConstructor defines a function that creates a new Service from an endpoint.
 type ServiceConstructor func(endpoint types.ServiceEndpoint) (types.Service, error)
 
+// constructorEntry pairs a ServiceConstructor with the predicate that decides whether it applies
+// to a given endpoint.
+type constructorEntry struct {
+	match func(types.ServiceEndpoint) bool
+	ctor  ServiceConstructor
+}
+
 // Registry manages the lifecycle of services-library.
 type Registry struct {
-	obs     *observability.Observability
-	metrics *metrics.ServicesMetrics
-	cache   *ServiceCache
+	obs          *observability.Observability
+	metrics      *metrics.ServicesMetrics
+	cache        *ServiceCache
+	constructors []constructorEntry
 }
 
-// NewRegistry creates a new Registry instance with observability-library and metrics.
+// NewRegistry creates a new Registry instance with observability-library and metrics, with the
+// built-in HTTP/gRPC/TCP/Docker Swarm/Kubernetes constructors registered ahead of the generic
+// fallback. The Docker Swarm and Kubernetes strategies stamp endpoint.Type with their own Name()
+// ("docker_swarm"/"kubernetes"), so endpoints they discover build into a SwarmService/
+// KubernetesService -- and so support Logs -- without the caller having to RegisterConstructor
+// manually.
 func NewRegistry(obs *observability.Observability, met *metrics.ServicesMetrics) *Registry {
 	serviceCache := NewCache(obs.Logger)
-	return &Registry{
+	r := &Registry{
 		obs:     obs,
 		metrics: met,
 		cache:   serviceCache,
 	}
+
+	r.RegisterConstructor(
+		func(endpoint types.ServiceEndpoint) bool { return endpoint.Type == "http" },
+		func(endpoint types.ServiceEndpoint) (types.Service, error) {
+			return NewHTTPProbeService(obs.Logger, endpoint), nil
+		},
+	)
+	r.RegisterConstructor(
+		func(endpoint types.ServiceEndpoint) bool { return endpoint.Type == "grpc" },
+		func(endpoint types.ServiceEndpoint) (types.Service, error) {
+			return NewGRPCHealthService(obs.Logger, endpoint), nil
+		},
+	)
+	r.RegisterConstructor(
+		func(endpoint types.ServiceEndpoint) bool { return endpoint.Type == "tcp" },
+		func(endpoint types.ServiceEndpoint) (types.Service, error) {
+			return NewTCPProbeService(obs.Logger, endpoint), nil
+		},
+	)
+	r.RegisterConstructor(
+		func(endpoint types.ServiceEndpoint) bool { return endpoint.Type == "docker_swarm" },
+		func(endpoint types.ServiceEndpoint) (types.Service, error) {
+			return NewSwarmService(endpoint), nil
+		},
+	)
+	r.RegisterConstructor(
+		func(endpoint types.ServiceEndpoint) bool { return endpoint.Type == "kubernetes" },
+		func(endpoint types.ServiceEndpoint) (types.Service, error) {
+			return NewKubernetesService(endpoint), nil
+		},
+	)
+
+	return r
+}
+
+// RegisterConstructor adds a typed ServiceConstructor tried, in registration order, before the
+// generic fallback in Register. The first constructor whose match returns true for an endpoint
+// builds it; this lets callers plug in their own Service types (gRPC, HTTP, Kafka, ...) without
+// forking the library.
+func (r *Registry) RegisterConstructor(match func(types.ServiceEndpoint) bool, ctor ServiceConstructor) {
+	r.constructors = append(r.constructors, constructorEntry{match: match, ctor: ctor})
 }
 
-// Register creates and registers a service.
+// Register creates and registers a service, dispatching to the first registered constructor
+// whose match matches endpoint, or the generic Service if none do.
 func (r *Registry) Register(endpoint types.ServiceEndpoint) (types.Service, error) {
 	// TODO: Improve check by Ports/Address/Tags
 	if r.cache.exists(endpoint.Name) {
@@ -38,12 +93,27 @@ func (r *Registry) Register(endpoint types.ServiceEndpoint) (types.Service, erro
 		return nil, fmt.Errorf("service already registered: %s", endpoint.Name)
 	}
 
-	service := NewService(endpoint)
+	service, err := r.build(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build service %s: %w", endpoint.Name, err)
+	}
+
 	r.cache.store(endpoint.Name, service)
-	r.obs.Logger.Info("Service registered", zap.String("service", endpoint.Name))
+	r.obs.Logger.Info("Service registered", zap.String("service", endpoint.Name), zap.String("type", service.Type()))
 	return service, nil
 }
 
+// build constructs a typed Service for endpoint using the first matching registered constructor,
+// falling back to the generic Service if none match.
+func (r *Registry) build(endpoint types.ServiceEndpoint) (types.Service, error) {
+	for _, entry := range r.constructors {
+		if entry.match(endpoint) {
+			return entry.ctor(endpoint)
+		}
+	}
+	return NewService(endpoint), nil
+}
+
 func (r *Registry) Unregister(name string) error {
 	// Check if the service exists
 	service, exists := r.cache.get(name)
@@ -98,6 +168,22 @@ func (r *Registry) StopAll(ctx context.Context) error {
 	})
 }
 
+// Logs streams logs for the named service when it implements types.LoggableService, and returns
+// an error otherwise -- not every backend (e.g. the fallback Service) supports log retrieval.
+func (r *Registry) Logs(ctx context.Context, name string, opts types.LogOptions) (<-chan types.LogEntry, error) {
+	service, exists := r.cache.get(name)
+	if !exists {
+		return nil, fmt.Errorf("service not found: %s", name)
+	}
+
+	loggable, ok := service.(types.LoggableService)
+	if !ok {
+		return nil, fmt.Errorf("service %s does not support log retrieval", name)
+	}
+
+	return loggable.Logs(ctx, opts)
+}
+
 func (r *Registry) List() []types.Service {
 	var servicesList []types.Service
 	r.cache.rangeAll(func(name string, service types.Service) {
@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/goletan/services-library/shared/types"
+)
+
+func TestRegistry_Build_MatchesInRegistrationOrder(t *testing.T) {
+	r := &Registry{}
+
+	r.RegisterConstructor(
+		func(endpoint types.ServiceEndpoint) bool { return endpoint.Type == "http" },
+		func(endpoint types.ServiceEndpoint) (types.Service, error) {
+			return &Service{ServiceType: "first"}, nil
+		},
+	)
+	r.RegisterConstructor(
+		func(endpoint types.ServiceEndpoint) bool { return endpoint.Type == "http" },
+		func(endpoint types.ServiceEndpoint) (types.Service, error) {
+			return &Service{ServiceType: "second"}, nil
+		},
+	)
+
+	service, err := r.build(types.ServiceEndpoint{Type: "http"})
+	if err != nil {
+		t.Fatalf("build returned error: %v", err)
+	}
+	if service.Type() != "first" {
+		t.Errorf("expected the first matching constructor to win, got %q", service.Type())
+	}
+}
+
+func TestRegistry_Build_FallsBackToGenericService(t *testing.T) {
+	r := &Registry{}
+
+	r.RegisterConstructor(
+		func(endpoint types.ServiceEndpoint) bool { return endpoint.Type == "http" },
+		func(endpoint types.ServiceEndpoint) (types.Service, error) { return &Service{ServiceType: "http"}, nil },
+	)
+
+	endpoint := types.ServiceEndpoint{Name: "svc", Address: "10.0.0.1", Type: "unknown"}
+	service, err := r.build(endpoint)
+	if err != nil {
+		t.Fatalf("build returned error: %v", err)
+	}
+
+	generic, ok := service.(*Service)
+	if !ok {
+		t.Fatalf("expected build to fall back to the generic Service, got %T", service)
+	}
+	if generic.Name() != "svc" || generic.Address() != "10.0.0.1" {
+		t.Errorf("expected the generic Service to be built from the endpoint, got %+v", generic)
+	}
+}
+
+func TestRegistry_Build_NoConstructorsFallsBack(t *testing.T) {
+	r := &Registry{}
+
+	service, err := r.build(types.ServiceEndpoint{Name: "svc", Type: "http"})
+	if err != nil {
+		t.Fatalf("build returned error: %v", err)
+	}
+	if _, ok := service.(*Service); !ok {
+		t.Errorf("expected the generic Service when no constructors are registered, got %T", service)
+	}
+}
@@ -0,0 +1,147 @@
+package registry
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/goletan/services-library/shared/types"
+)
+
+// swarmLogLinePrefix matches the "<service>.<slot>.<task-id>@<node>    | " prefix Docker adds to
+// service log lines once more than one task is being streamed, so TaskID can be recovered.
+var swarmLogLinePrefix = regexp.MustCompile(`^\S+\.\d+\.(\S+)@\S+\s*\|\s?(.*)$`)
+
+// SwarmService is a Docker Swarm-backed Service that additionally implements types.LoggableService
+// by demultiplexing cli.ServiceLogs' stdcopy-framed stream into per-line LogEntry values.
+type SwarmService struct {
+	ServiceName    string
+	ServiceAddress string
+	ServiceID      string
+	Ports          []types.ServicePort
+	Tags           map[string]string
+}
+
+// NewSwarmService builds a SwarmService from a discovered endpoint, pulling the Swarm service ID
+// out of the "__meta_docker_service_id" meta-label the Docker Swarm strategy attaches.
+func NewSwarmService(endpoint types.ServiceEndpoint) *SwarmService {
+	return &SwarmService{
+		ServiceName:    endpoint.Name,
+		ServiceAddress: endpoint.Address,
+		ServiceID:      endpoint.MetaLabels["__meta_docker_service_id"],
+		Ports:          endpoint.Ports,
+		Tags:           endpoint.Tags,
+	}
+}
+
+func (s *SwarmService) Name() string                    { return s.ServiceName }
+func (s *SwarmService) Type() string                    { return "docker_swarm" }
+func (s *SwarmService) Address() string                 { return s.ServiceAddress }
+func (s *SwarmService) Metadata() map[string]string     { return s.Tags }
+func (s *SwarmService) Initialize() error               { return nil }
+func (s *SwarmService) Start(ctx context.Context) error { return nil }
+func (s *SwarmService) Stop(ctx context.Context) error  { return nil }
+
+// Logs streams logs for every task of the Swarm service, demultiplexing stdout/stderr via
+// stdcopy and splitting on newlines so each LogEntry is one line.
+func (s *SwarmService) Logs(ctx context.Context, opts types.LogOptions) (<-chan types.LogEntry, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Docker client: %w", err)
+	}
+
+	reader, err := cli.ServiceLogs(ctx, s.ServiceID, containertypes.LogsOptions{
+		Follow:     opts.Follow,
+		Tail:       swarmTailOption(opts.Tail),
+		Since:      swarmSinceOption(opts.Since),
+		Timestamps: opts.Timestamps,
+		ShowStdout: opts.Stdout,
+		ShowStderr: opts.Stderr,
+	})
+	if err != nil {
+		cli.Close()
+		return nil, fmt.Errorf("failed to stream logs for service %s: %w", s.ServiceName, err)
+	}
+
+	entries := make(chan types.LogEntry)
+	stdoutR, stdoutW := io.Pipe()
+	stderrR, stderrW := io.Pipe()
+
+	go func() {
+		defer stdoutW.Close()
+		defer stderrW.Close()
+		_, _ = stdcopy.StdCopy(stdoutW, stderrW, reader)
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go demuxSwarmLogs(ctx, stdoutR, "stdout", opts.Timestamps, entries, &wg)
+	go demuxSwarmLogs(ctx, stderrR, "stderr", opts.Timestamps, entries, &wg)
+
+	go func() {
+		wg.Wait()
+		reader.Close()
+		cli.Close()
+		close(entries)
+	}()
+
+	return entries, nil
+}
+
+func demuxSwarmLogs(ctx context.Context, r io.Reader, stream string, timestamps bool, entries chan<- types.LogEntry, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		entry := parseSwarmLogLine(scanner.Text(), timestamps)
+		entry.Stream = stream
+
+		select {
+		case entries <- entry:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func parseSwarmLogLine(raw string, timestamps bool) types.LogEntry {
+	entry := types.LogEntry{Line: raw}
+
+	rest := raw
+	if m := swarmLogLinePrefix.FindStringSubmatch(raw); m != nil {
+		entry.TaskID = m[1]
+		rest = m[2]
+	}
+
+	if timestamps {
+		if ts, line, ok := splitLogTimestamp(rest); ok {
+			entry.Timestamp = ts
+			rest = line
+		}
+	}
+
+	entry.Line = rest
+	return entry
+}
+
+func swarmTailOption(tail int) string {
+	if tail <= 0 {
+		return "all"
+	}
+	return strconv.Itoa(tail)
+}
+
+func swarmSinceOption(since time.Time) string {
+	if since.IsZero() {
+		return ""
+	}
+	return since.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	logger "github.com/goletan/logger-library/pkg"
+	"github.com/goletan/services-library/shared/types"
+	"go.uber.org/zap"
+)
+
+// TCPProbeService is a Service backed by periodic TCP dial probes against the endpoint's address,
+// giving Start/Stop real behavior instead of the generic Service's no-ops.
+type TCPProbeService struct {
+	logger   *logger.ZapLogger
+	endpoint types.ServiceEndpoint
+	cancel   context.CancelFunc
+}
+
+// NewTCPProbeService builds a TCPProbeService for endpoint.
+func NewTCPProbeService(log *logger.ZapLogger, endpoint types.ServiceEndpoint) *TCPProbeService {
+	return &TCPProbeService{logger: log, endpoint: endpoint}
+}
+
+func (t *TCPProbeService) Name() string                { return t.endpoint.Name }
+func (t *TCPProbeService) Type() string                { return "tcp" }
+func (t *TCPProbeService) Address() string             { return t.endpoint.Address }
+func (t *TCPProbeService) Metadata() map[string]string { return t.endpoint.Tags }
+
+func (t *TCPProbeService) Initialize() error {
+	if t.endpoint.Address == "" {
+		return fmt.Errorf("tcp service %s has no address to probe", t.endpoint.Name)
+	}
+	return nil
+}
+
+// Start dials target every defaultProbeInterval until Stop is called, logging rather than
+// failing on a probe error -- a transient connection refusal shouldn't tear the service down.
+func (t *TCPProbeService) Start(ctx context.Context) error {
+	probeCtx, cancel := context.WithCancel(ctx)
+	t.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(defaultProbeInterval)
+		defer ticker.Stop()
+
+		for {
+			t.probe()
+
+			select {
+			case <-probeCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (t *TCPProbeService) probe() {
+	target := t.target()
+	conn, err := net.DialTimeout("tcp", target, defaultProbeTimeout)
+	if err != nil {
+		t.logger.Warn("TCP health probe failed", zap.String("service", t.endpoint.Name), zap.String("target", target), zap.Error(err))
+		return
+	}
+	conn.Close()
+}
+
+func (t *TCPProbeService) Stop(ctx context.Context) error {
+	if t.cancel != nil {
+		t.cancel()
+	}
+	return nil
+}
+
+// target returns the endpoint's address, appending its first configured port if any.
+func (t *TCPProbeService) target() string {
+	if len(t.endpoint.Ports) > 0 {
+		return net.JoinHostPort(t.endpoint.Address, strconv.Itoa(t.endpoint.Ports[0].Port))
+	}
+	return t.endpoint.Address
+}
@@ -0,0 +1,185 @@
+// Package relabel implements a Prometheus-style relabeling pipeline over discovered service
+// endpoints, letting operators filter and rewrite endpoints by their meta-labels/tags through
+// configuration alone.
+package relabel
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/goletan/services-library/shared/types"
+)
+
+const (
+	defaultSeparator   = ";"
+	defaultReplacement = "$1"
+	metaLabelPrefix    = "__meta_"
+	addressLabel       = "__address__"
+	nameLabel          = "__name__"
+)
+
+// compiledConfig is a RelabelConfig with its regex pre-compiled, since the same pipeline runs
+// against every endpoint.
+type compiledConfig struct {
+	cfg   types.RelabelConfig
+	regex *regexp.Regexp
+}
+
+// Process runs configs, in order, against each endpoint's combined meta-label/tag set and
+// returns the surviving, possibly-rewritten endpoints. An endpoint is dropped from the result as
+// soon as a "keep" or "drop" action rejects it.
+func Process(endpoints []types.ServiceEndpoint, configs []types.RelabelConfig) ([]types.ServiceEndpoint, error) {
+	if len(configs) == 0 {
+		return endpoints, nil
+	}
+
+	compiled := make([]compiledConfig, 0, len(configs))
+	for _, cfg := range configs {
+		cc, err := compile(cfg)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, cc)
+	}
+
+	result := make([]types.ServiceEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		labels := mergedLabels(endpoint)
+
+		keep := true
+		for _, cc := range compiled {
+			if keep = cc.apply(labels); !keep {
+				break
+			}
+		}
+
+		if keep {
+			result = append(result, applyLabels(endpoint, labels))
+		}
+	}
+
+	return result, nil
+}
+
+func compile(cfg types.RelabelConfig) (compiledConfig, error) {
+	pattern := cfg.Regex
+	if pattern == "" {
+		pattern = "(.*)"
+	}
+
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return compiledConfig{}, fmt.Errorf("invalid relabel regex %q: %w", cfg.Regex, err)
+	}
+
+	return compiledConfig{cfg: cfg, regex: re}, nil
+}
+
+func (c compiledConfig) separator() string {
+	if c.cfg.Separator != "" {
+		return c.cfg.Separator
+	}
+	return defaultSeparator
+}
+
+func (c compiledConfig) replacement() string {
+	if c.cfg.Replacement != "" {
+		return c.cfg.Replacement
+	}
+	return defaultReplacement
+}
+
+// apply evaluates the config against labels, mutating it in place for rewriting actions, and
+// reports whether the endpoint should still be kept.
+func (c compiledConfig) apply(labels map[string]string) bool {
+	values := make([]string, len(c.cfg.SourceLabels))
+	for i, name := range c.cfg.SourceLabels {
+		values[i] = labels[name]
+	}
+	value := strings.Join(values, c.separator())
+
+	switch c.cfg.Action {
+	case types.RelabelActionDrop:
+		return !c.regex.MatchString(value)
+	case types.RelabelActionReplace:
+		if idx := c.regex.FindStringSubmatchIndex(value); idx != nil && c.cfg.TargetLabel != "" {
+			labels[c.cfg.TargetLabel] = string(c.regex.ExpandString(nil, c.replacement(), value, idx))
+		}
+		return true
+	case types.RelabelActionLabelMap:
+		names := make([]string, 0, len(labels))
+		for name := range labels {
+			names = append(names, name)
+		}
+		for _, name := range names {
+			if idx := c.regex.FindStringSubmatchIndex(name); idx != nil {
+				labels[string(c.regex.ExpandString(nil, c.replacement(), name, idx))] = labels[name]
+			}
+		}
+		return true
+	case types.RelabelActionLabelDrop:
+		for name := range labels {
+			if c.regex.MatchString(name) {
+				delete(labels, name)
+			}
+		}
+		return true
+	case types.RelabelActionLabelKeep:
+		for name := range labels {
+			if !c.regex.MatchString(name) {
+				delete(labels, name)
+			}
+		}
+		return true
+	case types.RelabelActionKeep, "":
+		return c.regex.MatchString(value)
+	default:
+		return true
+	}
+}
+
+// mergedLabels combines an endpoint's meta-labels and tags into a single label set, plus the
+// Prometheus-style "__address__"/"__name__" pseudo-labels so source_labels can match on them.
+func mergedLabels(endpoint types.ServiceEndpoint) map[string]string {
+	labels := make(map[string]string, len(endpoint.MetaLabels)+len(endpoint.Tags)+2)
+	for key, value := range endpoint.Tags {
+		labels[key] = value
+	}
+	for key, value := range endpoint.MetaLabels {
+		labels[key] = value
+	}
+	labels[nameLabel] = endpoint.Name
+	labels[addressLabel] = endpoint.Address
+
+	return labels
+}
+
+// applyLabels writes the (possibly rewritten) label set back onto a copy of endpoint, splitting
+// it back out into MetaLabels/Tags and honoring rewrites of the "__name__"/"__address__" pseudo-labels.
+func applyLabels(endpoint types.ServiceEndpoint, labels map[string]string) types.ServiceEndpoint {
+	result := endpoint
+	if name, ok := labels[nameLabel]; ok {
+		result.Name = name
+	}
+	if address, ok := labels[addressLabel]; ok {
+		result.Address = address
+	}
+
+	tags := make(map[string]string)
+	metaLabels := make(map[string]string)
+	for key, value := range labels {
+		switch {
+		case key == nameLabel || key == addressLabel:
+			continue
+		case strings.HasPrefix(key, metaLabelPrefix):
+			metaLabels[key] = value
+		default:
+			tags[key] = value
+		}
+	}
+
+	result.Tags = tags
+	result.MetaLabels = metaLabels
+	return result
+}
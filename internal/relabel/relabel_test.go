@@ -0,0 +1,180 @@
+package relabel
+
+import (
+	"testing"
+
+	"github.com/goletan/services-library/shared/types"
+)
+
+func TestProcess_NoConfigs(t *testing.T) {
+	endpoints := []types.ServiceEndpoint{{Name: "svc"}}
+
+	result, err := Process(endpoints, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 || result[0].Name != "svc" {
+		t.Errorf("expected endpoints to pass through unchanged, got %+v", result)
+	}
+}
+
+func TestProcess_KeepDrop(t *testing.T) {
+	tests := []struct {
+		name   string
+		config types.RelabelConfig
+		labels map[string]string
+		keep   bool
+	}{
+		{
+			name: "keep matches",
+			config: types.RelabelConfig{
+				SourceLabels: []string{"env"},
+				Action:       types.RelabelActionKeep,
+				Regex:        "prod",
+			},
+			labels: map[string]string{"env": "prod"},
+			keep:   true,
+		},
+		{
+			name: "keep rejects non-match",
+			config: types.RelabelConfig{
+				SourceLabels: []string{"env"},
+				Action:       types.RelabelActionKeep,
+				Regex:        "prod",
+			},
+			labels: map[string]string{"env": "staging"},
+			keep:   false,
+		},
+		{
+			name: "drop matches",
+			config: types.RelabelConfig{
+				SourceLabels: []string{"env"},
+				Action:       types.RelabelActionDrop,
+				Regex:        "staging",
+			},
+			labels: map[string]string{"env": "staging"},
+			keep:   false,
+		},
+		{
+			name: "drop ignores non-match",
+			config: types.RelabelConfig{
+				SourceLabels: []string{"env"},
+				Action:       types.RelabelActionDrop,
+				Regex:        "staging",
+			},
+			labels: map[string]string{"env": "prod"},
+			keep:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := types.ServiceEndpoint{Name: "svc", Tags: tt.labels}
+
+			result, err := Process([]types.ServiceEndpoint{endpoint}, []types.RelabelConfig{tt.config})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			kept := len(result) == 1
+			if kept != tt.keep {
+				t.Errorf("expected keep=%v, got %v (result=%+v)", tt.keep, kept, result)
+			}
+		})
+	}
+}
+
+func TestProcess_Replace(t *testing.T) {
+	endpoint := types.ServiceEndpoint{
+		Name: "svc",
+		Tags: map[string]string{"version": "v1.2.3"},
+	}
+	cfg := types.RelabelConfig{
+		SourceLabels: []string{"version"},
+		Action:       types.RelabelActionReplace,
+		Regex:        "v(.*)",
+		TargetLabel:  "semver",
+	}
+
+	result, err := Process([]types.ServiceEndpoint{endpoint}, []types.RelabelConfig{cfg})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected endpoint to be kept, got %+v", result)
+	}
+	if got := result[0].Tags["semver"]; got != "1.2.3" {
+		t.Errorf("expected semver=1.2.3, got %q", got)
+	}
+}
+
+func TestProcess_LabelMapLabelDropLabelKeep(t *testing.T) {
+	endpoint := types.ServiceEndpoint{
+		Name: "svc",
+		MetaLabels: map[string]string{
+			"__meta_consul_dc":      "us-east",
+			"__meta_consul_service": "payments",
+		},
+	}
+
+	t.Run("labelmap copies matched meta-labels into new names", func(t *testing.T) {
+		cfg := types.RelabelConfig{
+			Action:      types.RelabelActionLabelMap,
+			Regex:       "__meta_consul_(.+)",
+			Replacement: "consul_$1",
+		}
+
+		result, err := Process([]types.ServiceEndpoint{endpoint}, []types.RelabelConfig{cfg})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := result[0].Tags["consul_dc"]; got != "us-east" {
+			t.Errorf("expected labelmap to copy __meta_consul_dc into tag consul_dc, got %q", got)
+		}
+	})
+
+	t.Run("labeldrop removes matched labels", func(t *testing.T) {
+		cfg := types.RelabelConfig{
+			Action: types.RelabelActionLabelDrop,
+			Regex:  "__meta_consul_dc",
+		}
+
+		result, err := Process([]types.ServiceEndpoint{endpoint}, []types.RelabelConfig{cfg})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := result[0].MetaLabels["__meta_consul_dc"]; ok {
+			t.Errorf("expected __meta_consul_dc to be dropped, still present: %+v", result[0].MetaLabels)
+		}
+		if _, ok := result[0].MetaLabels["__meta_consul_service"]; !ok {
+			t.Errorf("expected __meta_consul_service to survive labeldrop")
+		}
+	})
+
+	t.Run("labelkeep removes everything not matched", func(t *testing.T) {
+		cfg := types.RelabelConfig{
+			Action: types.RelabelActionLabelKeep,
+			Regex:  "__meta_consul_dc",
+		}
+
+		result, err := Process([]types.ServiceEndpoint{endpoint}, []types.RelabelConfig{cfg})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := result[0].MetaLabels["__meta_consul_service"]; ok {
+			t.Errorf("expected __meta_consul_service to be dropped by labelkeep, still present: %+v", result[0].MetaLabels)
+		}
+		if _, ok := result[0].MetaLabels["__meta_consul_dc"]; !ok {
+			t.Errorf("expected __meta_consul_dc to survive labelkeep")
+		}
+	})
+}
+
+func TestProcess_InvalidRegex(t *testing.T) {
+	cfg := types.RelabelConfig{Action: types.RelabelActionKeep, Regex: "("}
+
+	_, err := Process([]types.ServiceEndpoint{{Name: "svc"}}, []types.RelabelConfig{cfg})
+	if err == nil {
+		t.Fatal("expected an error for an invalid regex, got nil")
+	}
+}
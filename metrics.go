@@ -1,7 +1,15 @@
 // /services/metrics.go
+//
+// Deprecated: this legacy package predates internal/metrics and registers against the default
+// Prometheus registry directly. If something else in the process has already registered a
+// "goletan_services_execution_duration_seconds" collector, Register returns nil and this package's
+// own collector stays unregistered, so its observations are not exported. New code should use
+// github.com/goletan/services-library/internal/metrics via pkg.NewServices instead.
 package services
 
 import (
+	"errors"
+
 	"github.com/goletan/observability/metrics"
 	"github.com/goletan/observability/utils"
 	"github.com/prometheus/client_golang/prometheus"
@@ -33,6 +41,10 @@ func InitMetrics() {
 
 func (em *ServicesMetrics) Register() error {
 	if err := prometheus.Register(ServiceExecutionDuration); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			return nil
+		}
 		return err
 	}
 
@@ -14,7 +14,7 @@ import (
 // Services encapsulates service discovery, registration, and lifecycle management.
 type Services struct {
 	cfg       *types.ServicesConfig
-	discovery *discovery.CompositeDiscovery
+	discovery *discovery.Manager
 	registry  *registry.Registry
 	metrics   *metrics.ServicesMetrics
 }
@@ -26,15 +26,13 @@ func NewServices(obs *observability.Observability) (*Services, error) {
 		obs.Logger.Fatal("Failed to load services-library configuration", zap.Error(err))
 	}
 
-	compositeDiscovery := discovery.NewCompositeDiscovery(obs.Logger, cfg)
-
-	// Initialize registry and metrics
 	newMetrics := metrics.InitMetrics(obs)
+	discoveryManager := discovery.NewManager(obs.Logger, cfg, newMetrics)
 	newRegistry := registry.NewRegistry(obs, newMetrics)
 
 	return &Services{
 		cfg:       cfg,
-		discovery: compositeDiscovery,
+		discovery: discoveryManager,
 		registry:  newRegistry,
 		metrics:   newMetrics,
 	}, nil
@@ -50,6 +48,13 @@ func (s *Services) Watch(ctx context.Context, filter *types.Filter) (<-chan type
 	return s.discovery.Watch(ctx, filter)
 }
 
+// ApplyDiscoveryConfig reconciles the discovery manager's providers to match cfg, without
+// restarting providers that are unaffected by the change.
+func (s *Services) ApplyDiscoveryConfig(cfg *types.ServicesConfig) error {
+	s.cfg = cfg
+	return s.discovery.ApplyConfig(cfg)
+}
+
 // Register registers a service in the registry.
 func (s *Services) Register(endpoint types.ServiceEndpoint) (types.Service, error) {
 	return s.registry.Register(endpoint)
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type ServicesConfig struct {
 	Discovery DiscoveryConfig `yaml:"discovery"`
 }
@@ -7,11 +9,79 @@ type ServicesConfig struct {
 type DiscoveryConfig struct {
 	Strategies      []StrategyConfig `yaml:"strategies"`
 	DefaultStrategy string           `yaml:"default_strategy"`
+	SyncPeriod      time.Duration    `yaml:"sync_period,omitempty"` // Interval at which the Manager re-emits its consolidated view
+	Relabel         []RelabelConfig  `yaml:"relabel,omitempty"`     // Relabeling pipeline applied to every discovered endpoint
+	Sinks           []SinkConfig     `yaml:"sinks,omitempty"`       // Fan discovered endpoints out to external systems (e.g. DNS)
+}
+
+// SinkConfig configures one discovery sink.
+type SinkConfig struct {
+	Name string        `yaml:"name"`
+	DNS  DNSSinkConfig `yaml:"dns,omitempty"` // For the "dns" sink
+}
+
+// DNSSinkConfig holds the connection and record options for the etcd-backed DNS sink.
+type DNSSinkConfig struct {
+	Endpoints     []string      `yaml:"endpoints,omitempty"`      // etcd v3 endpoints
+	Domain        string        `yaml:"domain,omitempty"`         // Root domain records are published under (e.g. "svc.cluster.local")
+	Namespace     string        `yaml:"namespace,omitempty"`      // SkyDNS-style namespace segment between domain and service name
+	TTL           time.Duration `yaml:"ttl,omitempty"`            // Record TTL (30s when unset)
+	ReconcileTick time.Duration `yaml:"reconcile_tick,omitempty"` // How often to list live endpoints and prune stale keys
+}
+
+// RelabelConfig mirrors Prometheus' relabeling configuration, letting operators filter and
+// rewrite discovered endpoints' meta-labels/tags without code changes.
+type RelabelConfig struct {
+	SourceLabels []string `yaml:"source_labels,omitempty"`
+	Separator    string   `yaml:"separator,omitempty"`
+	Regex        string   `yaml:"regex,omitempty"`
+	Action       string   `yaml:"action,omitempty"`
+	TargetLabel  string   `yaml:"target_label,omitempty"`
+	Replacement  string   `yaml:"replacement,omitempty"`
 }
 
+// Supported RelabelConfig.Action values.
+const (
+	RelabelActionKeep      = "keep"
+	RelabelActionDrop      = "drop"
+	RelabelActionReplace   = "replace"
+	RelabelActionLabelMap  = "labelmap"
+	RelabelActionLabelDrop = "labeldrop"
+	RelabelActionLabelKeep = "labelkeep"
+)
+
 type StrategyConfig struct {
-	Name      string `yaml:"name"`
-	Namespace string `yaml:"namespace,omitempty"` // For Kubernetes
-	Network   string `yaml:"network,omitempty"`   // For Docker
-	Domain    string `yaml:"domain,omitempty"`    // For DNS
+	Name            string           `yaml:"name"`
+	Namespace       string           `yaml:"namespace,omitempty"`        // For Kubernetes
+	Network         string           `yaml:"network,omitempty"`          // For Docker
+	Domain          string           `yaml:"domain,omitempty"`           // For DNS
+	Service         string           `yaml:"service,omitempty"`          // For DNS SRV (e.g. "grpc")
+	Proto           string           `yaml:"proto,omitempty"`            // For DNS SRV (e.g. "tcp")
+	Files           []string         `yaml:"files,omitempty"`            // For file SD (glob patterns)
+	RefreshInterval time.Duration    `yaml:"refresh_interval,omitempty"` // Polling interval for Watch, where applicable
+	Consul          ConsulConfig     `yaml:"consul,omitempty"`           // For Consul
+	Strategies      []StrategyConfig `yaml:"strategies,omitempty"`       // For composite: the sub-strategies to fan out to
+	MergePolicy     string           `yaml:"merge_policy,omitempty"`     // For composite: "prefer_first" (default), "prefer_newest", or "union"
+}
+
+// ConsulConfig holds the connection and query options for the Consul discovery strategy.
+type ConsulConfig struct {
+	Address     string            `yaml:"address,omitempty"`
+	Datacenter  string            `yaml:"datacenter,omitempty"`
+	Token       string            `yaml:"token,omitempty"`
+	Service     string            `yaml:"service,omitempty"`
+	Tag         string            `yaml:"tag,omitempty"`
+	AllowStale  bool              `yaml:"allow_stale,omitempty"`
+	PassingOnly bool              `yaml:"passing_only,omitempty"`
+	NodeMeta    map[string]string `yaml:"node_meta,omitempty"`
+	TLS         ConsulTLSConfig   `yaml:"tls,omitempty"`
+}
+
+// ConsulTLSConfig configures TLS for the Consul discovery strategy's HTTP client. Leaving it
+// zero-valued keeps the client on plain HTTP, matching consulapi.DefaultConfig.
+type ConsulTLSConfig struct {
+	CAFile             string `yaml:"ca_file,omitempty"`
+	CertFile           string `yaml:"cert_file,omitempty"`
+	KeyFile            string `yaml:"key_file,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
 }
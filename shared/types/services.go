@@ -1,6 +1,9 @@
 package types
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type ServiceFactory func(endpoint ServiceEndpoint) Service
 
@@ -15,6 +18,31 @@ type Service interface {
 	Metadata() map[string]string
 }
 
+// LoggableService is an optional capability a Service implementation can provide to stream its
+// logs. Not every backend supports it (e.g. the fallback Service), so callers type-assert for it
+// rather than it being part of the base Service interface.
+type LoggableService interface {
+	Logs(ctx context.Context, opts LogOptions) (<-chan LogEntry, error)
+}
+
+// LogOptions configures a LoggableService.Logs call.
+type LogOptions struct {
+	Follow     bool      // Stream new lines as they're written instead of returning once caught up.
+	Tail       int       // Number of most-recent lines to return; 0 means "all available".
+	Since      time.Time // Only return lines written at or after Since; the zero value means no lower bound.
+	Timestamps bool      // Prefix/parse each line's original timestamp.
+	Stdout     bool      // Include stdout lines.
+	Stderr     bool      // Include stderr lines.
+}
+
+// LogEntry is a single demultiplexed log line produced by a LoggableService.
+type LogEntry struct {
+	Stream    string    // "stdout" or "stderr".
+	Timestamp time.Time // When the line was written; zero if Timestamps wasn't requested or the backend didn't report it.
+	Line      string
+	TaskID    string // The backend-specific task/pod/container that produced the line, if known.
+}
+
 // ServiceEvent represents an event related to a service, such as its addition,
 // modification, or deletion. It contains metadata about the event type and the
 // associated service endpoint details.
@@ -23,26 +51,30 @@ type Service interface {
 //   - Type: Describes the nature of the event (e.g., "ADDED", "MODIFIED", "DELETED").
 //   - Service: Provides information about the service endpoint involved in the event,
 //     including its name, address, ports, and optional metadata such as version and tags.
+//   - Source: The name of the strategy that produced the event, set by strategies (such as
+//     CompositeStrategy) that fan multiple underlying sources into one event stream.
 type ServiceEvent struct {
 	Type    string
 	Service ServiceEndpoint
+	Source  string `yaml:"-" json:"-"`
 }
 
 // ServiceEndpoint represents the metadata and connection details for a service.
 type ServiceEndpoint struct {
-	Name    string            // The name of the service (e.g., "auth-service").
-	Address string            // The IP or hostname of the service.
-	Ports   []ServicePort     // List of exposed ports and their purposes.
-	Version string            // version of the service for future use (e.g., "1.0").
-	Tags    map[string]string // Optional: tags for categorization or discovery filters (e.g., ["grpc", "my-service"]).
-	Type    string
+	Name       string            `yaml:"name" json:"name"`                           // The name of the service (e.g., "auth-service").
+	Address    string            `yaml:"address" json:"address"`                     // The IP or hostname of the service.
+	Ports      []ServicePort     `yaml:"ports,omitempty" json:"ports,omitempty"`     // List of exposed ports and their purposes.
+	Version    string            `yaml:"version,omitempty" json:"version,omitempty"` // version of the service for future use (e.g., "1.0").
+	Tags       map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`       // Optional: tags for categorization or discovery filters (e.g., ["grpc", "my-service"]).
+	MetaLabels map[string]string `yaml:"-" json:"-"`                                 // Read-only discovery metadata (e.g. "__meta_kubernetes_service_namespace"), distinct from user-facing Tags.
+	Type       string            `yaml:"type,omitempty" json:"type,omitempty"`
 }
 
 // ServicePort represents the details of a single port.
 type ServicePort struct {
-	Name     string // The name of the port (e.g., "grpc", "http").
-	Port     int    // The port number.
-	Protocol string // The protocol used (e.g., "TCP", "UDP").
+	Name     string `yaml:"name,omitempty" json:"name,omitempty"`         // The name of the port (e.g., "grpc", "http").
+	Port     int    `yaml:"port" json:"port"`                             // The port number.
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"` // The protocol used (e.g., "TCP", "UDP").
 }
 
 // Filter Allow filtering of services by tags or labels.